@@ -0,0 +1,103 @@
+package pin
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// CIProvider identifies the continuous integration service the program runs on.
+type CIProvider int
+
+const (
+	// CINone disables CI-specific output.
+	CINone CIProvider = iota
+	// CIGitHubActions folds output with ::group:: and annotates failures with ::error::.
+	CIGitHubActions
+	// CIGitLab folds output with section_start and section_end markers.
+	CIGitLab
+)
+
+// String returns the name of the provider.
+func (c CIProvider) String() string {
+	switch c {
+	case CIGitHubActions:
+		return "github-actions"
+	case CIGitLab:
+		return "gitlab"
+	default:
+		return "none"
+	}
+}
+
+// DetectCI returns the CI provider indicated by the environment:
+// GITHUB_ACTIONS=true for GitHub Actions and GITLAB_CI=true for GitLab CI.
+func DetectCI() CIProvider {
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") == "true":
+		return CIGitHubActions
+	case os.Getenv("GITLAB_CI") == "true":
+		return CIGitLab
+	default:
+		return CINone
+	}
+}
+
+// WithCIProvider overrides the CI provider detected by DetectCI. In
+// non-interactive mode, each run of the spinner is wrapped in a collapsible
+// section of the CI log, and on GitHub Actions Fail also emits an error
+// annotation. Pass CINone to disable this.
+//
+// Example usage:
+//
+//	p := pin.New("Building", pin.WithCIProvider(pin.CINone))
+func WithCIProvider(c CIProvider) Option {
+	return func(p *Pin) {
+		p.ci = c
+	}
+}
+
+// ciSections numbers GitLab sections, whose names must be unique within a job.
+// Names include the process ID, as other tools using pin may run in the job.
+var ciSections uint64
+
+// ciStart opens a collapsible section for the current run.
+// The caller must hold p.mu.
+func (p *Pin) ciStart() {
+	header := p.header()
+
+	switch p.ci {
+	case CIGitHubActions:
+		_, _ = fmt.Fprintf(p.out, "::group::%s\n", header)
+	case CIGitLab:
+		p.ciSection = fmt.Sprintf("pin_%d_%d", os.Getpid(), atomic.AddUint64(&ciSections, 1))
+		_, _ = fmt.Fprintf(p.out, "\033[0Ksection_start:%d:%s\r\033[0K%s\n", time.Now().Unix(), p.ciSection, header)
+	}
+}
+
+// ciEnd closes the section opened by ciStart and, on GitHub Actions,
+// annotates a failure with message.
+// The caller must hold p.mu.
+func (p *Pin) ciEnd(res result, message string) {
+	switch p.ci {
+	case CIGitHubActions:
+		_, _ = fmt.Fprintln(p.out, "::endgroup::")
+		if res == resultFailed {
+			_, _ = fmt.Fprintf(p.out, "::error::%s\n", escapeGitHubData(p.clean(message)))
+		}
+	case CIGitLab:
+		if p.ciSection != "" {
+			_, _ = fmt.Fprintf(p.out, "\033[0Ksection_end:%d:%s\r\033[0K\n", time.Now().Unix(), p.ciSection)
+			p.ciSection = ""
+		}
+	}
+}
+
+// escapeGitHubData escapes s for use as the message of a workflow command.
+func escapeGitHubData(s string) string {
+	s = strings.Replace(s, "%", "%25", -1)
+	s = strings.Replace(s, "\r", "%0D", -1)
+	return strings.Replace(s, "\n", "%0A", -1)
+}
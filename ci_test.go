@@ -0,0 +1,96 @@
+package pin_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/yarlson/pin"
+)
+
+// TestMain clears the CI environment and the terminal width so that the
+// tests see the same output locally and on CI.
+func TestMain(m *testing.M) {
+	_ = os.Unsetenv("GITHUB_ACTIONS")
+	_ = os.Unsetenv("GITLAB_CI")
+	_ = os.Unsetenv("COLUMNS")
+	_ = os.Unsetenv("LINES")
+	os.Exit(m.Run())
+}
+
+func TestDetectCI(t *testing.T) {
+	defer os.Unsetenv("GITHUB_ACTIONS")
+	defer os.Unsetenv("GITLAB_CI")
+
+	if got := pin.DetectCI(); got != pin.CINone {
+		t.Errorf("Expected %v, got %v", pin.CINone, got)
+	}
+	_ = os.Setenv("GITLAB_CI", "true")
+	if got := pin.DetectCI(); got != pin.CIGitLab {
+		t.Errorf("Expected %v, got %v", pin.CIGitLab, got)
+	}
+	_ = os.Setenv("GITHUB_ACTIONS", "true")
+	if got := pin.DetectCI(); got != pin.CIGitHubActions {
+		t.Errorf("Expected %v, got %v", pin.CIGitHubActions, got)
+	}
+}
+
+func TestGitHubActionsGroups(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Compiling", pin.WithWriter(&buf), pin.WithPrefix("build"), pin.WithCIProvider(pin.CIGitHubActions))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("Compiled")
+
+	want := "::group::build › Compiling\nCompiling\nCompiled\n::endgroup::\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Expected output %q, got %q", want, got)
+	}
+}
+
+func TestGitHubActionsErrorAnnotation(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Testing", pin.WithWriter(&buf), pin.WithCIProvider(pin.CIGitHubActions))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Fail("3 tests failed\n100% broken")
+
+	want := "::endgroup::\n::error::3 tests failed%0A100%25 broken\n"
+	if got := buf.String(); !strings.HasSuffix(got, want) {
+		t.Errorf("Expected output ending in %q, got %q", want, got)
+	}
+}
+
+func TestGitHubActionsGroupClosedOnCancel(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Testing", pin.WithWriter(&buf), pin.WithCIProvider(pin.CIGitHubActions))
+	cancel := p.Start(context.Background())
+	cancel()
+	waitStopped(t, p)
+
+	if got := buf.String(); !strings.HasSuffix(got, "::endgroup::\n") || strings.Contains(got, "::error::") {
+		t.Errorf("Expected the group to be closed without an annotation, got %q", got)
+	}
+}
+
+func TestGitLabSections(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Compiling", pin.WithWriter(&buf), pin.WithCIProvider(pin.CIGitLab))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Fail("link error")
+
+	re := regexp.MustCompile(`^\x1b\[0Ksection_start:\d+:(pin_\d+_\d+)\r\x1b\[0KCompiling\nCompiling\nlink error\n\x1b\[0Ksection_end:\d+:(pin_\d+_\d+)\r\x1b\[0K\n$`)
+	m := re.FindStringSubmatch(buf.String())
+	if m == nil || m[1] != m[2] {
+		t.Errorf("Expected a matching GitLab section, got %q", buf.String())
+	} else if prefix := fmt.Sprintf("pin_%d_", os.Getpid()); !strings.HasPrefix(m[1], prefix) {
+		t.Errorf("Expected the section name to start with %q, got %q", prefix, m[1])
+	}
+	if strings.Contains(buf.String(), "::error::") {
+		t.Errorf("Expected no GitHub annotation on GitLab, got %q", buf.String())
+	}
+}
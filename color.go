@@ -0,0 +1,68 @@
+package pin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// colorRGB marks a Color holding a 24-bit RGB value in its lower bits.
+const colorRGB Color = 1 << 24
+
+var colorNames = map[string]Color{
+	"default": ColorDefault,
+	"black":   ColorBlack,
+	"red":     ColorRed,
+	"green":   ColorGreen,
+	"yellow":  ColorYellow,
+	"blue":    ColorBlue,
+	"magenta": ColorMagenta,
+	"cyan":    ColorCyan,
+	"gray":    ColorGray,
+	"grey":    ColorGray,
+	"white":   ColorWhite,
+}
+
+// RGB returns a 24-bit true color. Terminals without true color support
+// approximate or ignore it.
+//
+// Example usage:
+//
+//	p := pin.New("Loading", pin.WithSpinnerColor(pin.RGB(0xff, 0x87, 0x00)))
+func RGB(r, g, b uint8) Color {
+	return colorRGB | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// ParseColor parses a color name such as "cyan" or a hex value such as
+// "#ff8700" or "#f80". Names are case-insensitive.
+func ParseColor(s string) (Color, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if c, ok := colorNames[name]; ok {
+		return c, nil
+	}
+	if !strings.HasPrefix(name, "#") {
+		return ColorDefault, fmt.Errorf("unknown color %q", s)
+	}
+	hex := name[1:]
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return ColorDefault, fmt.Errorf("invalid hex color %q", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return ColorDefault, fmt.Errorf("invalid hex color %q", s)
+	}
+	return RGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+}
+
+// isRGB reports whether the color holds a 24-bit RGB value.
+func (c Color) isRGB() bool {
+	return c&colorRGB != 0
+}
+
+// rgb returns the components of a 24-bit RGB color.
+func (c Color) rgb() (r, g, b uint8) {
+	return uint8(c >> 16), uint8(c >> 8), uint8(c)
+}
@@ -0,0 +1,44 @@
+package pin_test
+
+import (
+	"testing"
+
+	"github.com/yarlson/pin"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want pin.Color
+	}{
+		{"cyan", pin.ColorCyan},
+		{"Grey", pin.ColorGray},
+		{"default", pin.ColorDefault},
+		{"#ff8700", pin.RGB(0xff, 0x87, 0x00)},
+		{"#F80", pin.RGB(0xff, 0x88, 0x00)},
+	}
+	for _, tt := range tests {
+		got, err := pin.ParseColor(tt.in)
+		if err != nil {
+			t.Errorf("ParseColor(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseColor(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"purple", "#12345", "#gggggg", ""} {
+		if _, err := pin.ParseColor(in); err == nil {
+			t.Errorf("Expected ParseColor(%q) to fail", in)
+		}
+	}
+}
+
+func TestRGBString(t *testing.T) {
+	got := pin.RGB(1, 2, 3).String()
+	want := "\033[38;2;1;2;3m"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,37 @@
+package pin
+
+import (
+	"context"
+	"io/ioutil"
+)
+
+// contextKey is the key under which a spinner is stored in a context.
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries the spinner p, so that code
+// deeper in the call chain can report progress without taking a *Pin.
+//
+// Example usage:
+//
+//	p := pin.New("Installing")
+//	cancel := p.Start(ctx)
+//	defer cancel()
+//	install(pin.NewContext(ctx, p))
+func NewContext(ctx context.Context, p *Pin) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the spinner carried by ctx. If there is none, it
+// returns a no-op spinner whose methods are safe to call and do nothing, so
+// callers never need to check for nil:
+//
+//	pin.FromContext(ctx).UpdateMessage("resolving deps")
+func FromContext(ctx context.Context) *Pin {
+	if p, ok := ctx.Value(contextKey{}).(*Pin); ok && p != nil {
+		return p
+	}
+	p := basePin("")
+	p.out = ioutil.Discard
+	p.noop = true
+	return p
+}
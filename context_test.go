@@ -0,0 +1,46 @@
+package pin_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yarlson/pin"
+)
+
+func TestFromContextReturnsAttachedSpinner(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Installing", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+
+	ctx := pin.NewContext(context.Background(), p)
+	pin.FromContext(ctx).UpdateMessage("resolving deps")
+	if got := p.Message(); got != "resolving deps" {
+		t.Errorf("Expected message to be updated through the context, got %q", got)
+	}
+	pin.FromContext(ctx).Stop("Installed")
+	if !strings.Contains(buf.String(), "Installed") {
+		t.Errorf("Expected final message in output, got %q", buf.String())
+	}
+}
+
+func TestFromContextWithoutSpinnerIsNoop(t *testing.T) {
+	p := pin.FromContext(context.Background())
+	if p == nil {
+		t.Fatal("Expected a non-nil no-op spinner")
+	}
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.UpdateMessage("resolving deps")
+	p.SetPrefix("deps")
+	p.Stop("Done")
+	p.Fail("Failed")
+	if p.IsRunning() {
+		t.Error("Expected the no-op spinner never to run")
+	}
+
+	if pin.FromContext(pin.NewContext(context.Background(), nil)) == nil {
+		t.Error("Expected a no-op spinner for a nil spinner in the context")
+	}
+}
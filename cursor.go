@@ -0,0 +1,46 @@
+package pin
+
+import "fmt"
+
+const (
+	escHideCursor = "\033[?25l"
+	escShowCursor = "\033[?25h"
+)
+
+// WithHiddenCursor hides the terminal cursor while the spinner animates.
+// The cursor is shown again when the spinner stops, fails, is canceled or
+// paused, and when the process receives SIGINT or SIGTERM, so an interrupted
+// program never leaves the terminal without a cursor.
+//
+// To restore the cursor on those signals, pin briefly handles them, finishes
+// the spinner and then raises them again, so that the default behavior of
+// terminating the program still applies, unless the spinner handles them
+// itself (see WithSignalHandling). A program that handles the signals with
+// signal.Notify would then receive them twice; it should use
+// WithAppSignalHandler.
+func WithHiddenCursor() Option {
+	return func(p *Pin) {
+		p.hideCursor = true
+	}
+}
+
+// hideCursorLocked hides the cursor for the current run if configured and
+// reports whether it did. The caller must hold p.mu.
+func (p *Pin) hideCursorLocked() bool {
+	if !p.hideCursor || !p.interactive {
+		return false
+	}
+	_, _ = fmt.Fprint(p.out, escHideCursor)
+	p.cursorHidden = true
+	return true
+}
+
+// showCursorLocked shows the cursor if the current run hid it.
+// The caller must hold p.mu.
+func (p *Pin) showCursorLocked() {
+	if !p.cursorHidden {
+		return
+	}
+	_, _ = fmt.Fprint(p.out, escShowCursor)
+	p.cursorHidden = false
+}
@@ -0,0 +1,125 @@
+package pin_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+const (
+	hideCursor = "\033[?25l"
+	showCursor = "\033[?25h"
+)
+
+func TestHiddenCursorRestoredOnStopAndFail(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	for _, failed := range []bool{false, true} {
+		var buf syncBuffer
+		p := pin.New("Working", pin.WithWriter(&buf), pin.WithHiddenCursor())
+		cancel := p.Start(context.Background())
+		time.Sleep(50 * time.Millisecond)
+		if failed {
+			p.Fail("Failed")
+		} else {
+			p.Stop("Done")
+		}
+		cancel()
+
+		output := buf.String()
+		if !strings.HasPrefix(output, hideCursor) {
+			t.Errorf("Expected output to start by hiding the cursor, got %q", output)
+		}
+		if !strings.HasSuffix(output, showCursor) {
+			t.Errorf("Expected output to end by showing the cursor, got %q", output)
+		}
+	}
+}
+
+func TestHiddenCursorRestoredOnCancel(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithHiddenCursor())
+	cancel := p.Start(context.Background())
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	if !strings.HasSuffix(buf.String(), showCursor) {
+		t.Errorf("Expected cancellation to show the cursor, got %q", buf.String())
+	}
+}
+
+func TestHiddenCursorShownWhilePaused(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithHiddenCursor())
+	cancel := p.Start(context.Background())
+	defer cancel()
+
+	p.Pause()
+	if !strings.HasSuffix(buf.String(), showCursor) {
+		t.Errorf("Expected Pause to show the cursor, got %q", buf.String())
+	}
+	p.Resume()
+	if strings.Count(buf.String(), hideCursor) != 2 {
+		t.Errorf("Expected Resume to hide the cursor again, got %q", buf.String())
+	}
+	p.Stop()
+}
+
+func TestCursorNotHiddenInNonInteractiveMode(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithHiddenCursor())
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("Done")
+	if strings.Contains(buf.String(), hideCursor) || strings.Contains(buf.String(), showCursor) {
+		t.Errorf("Expected no cursor sequences in non-interactive mode, got %q", buf.String())
+	}
+}
+
+func TestHiddenCursorRestoredOnSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals are not supported on Windows")
+	}
+	if os.Getenv("PIN_TEST_CURSOR_SIGNAL") == "1" {
+		pin.ForceInteractive = true
+		p := pin.New("Working", pin.WithHiddenCursor())
+		p.Start(context.Background())
+		time.Sleep(50 * time.Millisecond)
+		proc, _ := os.FindProcess(os.Getpid())
+		_ = proc.Signal(syscall.SIGTERM)
+		time.Sleep(5 * time.Second)
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHiddenCursorRestoredOnSignal$")
+	cmd.Env = append(os.Environ(), "PIN_TEST_CURSOR_SIGNAL=1")
+	output, err := cmd.Output()
+
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		t.Fatalf("Expected the process to be terminated by the signal, got %v", err)
+	}
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); !ok || !status.Signaled() {
+		t.Errorf("Expected the signal to terminate the process, got %v", err)
+	}
+	if !strings.Contains(string(output), hideCursor) || !strings.HasSuffix(string(output), showCursor) {
+		t.Errorf("Expected the cursor to be shown before exiting, got %q", output)
+	}
+}
@@ -0,0 +1,38 @@
+package pin
+
+import "sync"
+
+var (
+	defaultsMu   sync.RWMutex
+	defaultsOpts []Option
+)
+
+// SetDefaults replaces the package-level options that New applies before its
+// own options. It lets an application configure the look of every spinner
+// once, e.g. in main, including spinners created deep inside libraries.
+// Calling SetDefaults with no options clears the defaults.
+// It is safe for concurrent use.
+//
+// Example usage:
+//
+//	pin.SetDefaults(
+//	    pin.WithTheme(pin.ThemeDots),
+//	    pin.WithWriter(os.Stderr),
+//	)
+func SetDefaults(opts ...Option) {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	defaultsOpts = append([]Option(nil), opts...)
+}
+
+// Defaults returns a copy of the options set by SetDefaults.
+// It can be used to restore previous defaults:
+//
+//	old := pin.Defaults()
+//	pin.SetDefaults(pin.WithTheme(pin.ThemeASCII))
+//	defer pin.SetDefaults(old...)
+func Defaults() []Option {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+	return append([]Option(nil), defaultsOpts...)
+}
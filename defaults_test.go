@@ -0,0 +1,79 @@
+package pin_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestSetDefaultsAppliesToNew(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	old := pin.Defaults()
+	defer pin.SetDefaults(old...)
+
+	var buf bytes.Buffer
+	pin.SetDefaults(pin.WithWriter(&buf), pin.WithDoneSymbol('!'), pin.WithPrefix("app"))
+
+	// Per-call options are applied after the defaults.
+	p := pin.New("Working", pin.WithPrefix("step"))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(150 * time.Millisecond)
+	p.Stop("Done")
+
+	output := buf.String()
+	if !strings.Contains(output, "!") {
+		t.Errorf("Expected default done symbol in output, got %q", output)
+	}
+	if !strings.Contains(output, "step") || strings.Contains(output, "app") {
+		t.Errorf("Expected per-call prefix to override default, got %q", output)
+	}
+}
+
+func TestDefaultsReturnsCopy(t *testing.T) {
+	old := pin.Defaults()
+	defer pin.SetDefaults(old...)
+
+	pin.SetDefaults(pin.WithPrefix("a"), pin.WithSeparator(":"))
+	got := pin.Defaults()
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 default options, got %d", len(got))
+	}
+	got[0] = nil
+	if pin.Defaults()[0] == nil {
+		t.Error("Expected Defaults to return a copy")
+	}
+
+	pin.SetDefaults()
+	if len(pin.Defaults()) != 0 {
+		t.Error("Expected SetDefaults with no options to clear the defaults")
+	}
+}
+
+func TestSetDefaultsConcurrent(t *testing.T) {
+	old := pin.Defaults()
+	defer pin.SetDefaults(old...)
+
+	var buf bytes.Buffer
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			pin.SetDefaults(pin.WithWriter(&buf))
+		}()
+		go func() {
+			defer wg.Done()
+			_ = pin.New("Concurrent")
+		}()
+	}
+	wg.Wait()
+}
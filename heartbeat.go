@@ -0,0 +1,58 @@
+package pin
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithHeartbeat periodically prints a line such as
+// "still running: Building (5m0s)" in non-interactive mode, so that long
+// steps do not look hung in CI logs and jobs are not killed for lack of
+// output. The interval restarts whenever a line is printed.
+// It has no effect when the output is a terminal.
+func WithHeartbeat(interval time.Duration) Option {
+	return func(p *Pin) {
+		p.heartbeatEvery = interval
+	}
+}
+
+// WithHeartbeatFormat sets the function that formats heartbeat lines.
+// It is not used if a log format is set with WithLogFormat.
+//
+// Example usage:
+//
+//	pin.WithHeartbeatFormat(func(msg string, elapsed time.Duration) string {
+//	    return fmt.Sprintf("[%v] %s", elapsed, msg)
+//	})
+func WithHeartbeatFormat(format func(message string, elapsed time.Duration) string) Option {
+	return func(p *Pin) {
+		p.heartbeatFormat = format
+	}
+}
+
+// defaultHeartbeatFormat formats heartbeat lines as "still running: <message> (<elapsed>)".
+func defaultHeartbeatFormat(message string, elapsed time.Duration) string {
+	return fmt.Sprintf("still running: %s (%v)", message, elapsed)
+}
+
+// heartbeat prints a heartbeat line if one is due at now.
+// The caller must hold p.mu.
+func (p *Pin) heartbeat(now time.Time) {
+	if p.interactive || p.state != StateRunning || p.heartbeatEvery <= 0 {
+		return
+	}
+	if now.Sub(p.lastPrinted) < p.heartbeatEvery {
+		return
+	}
+
+	if p.logFormat != nil {
+		p.printLog("running", p.message)
+		return
+	}
+	format := p.heartbeatFormat
+	if format == nil {
+		format = defaultHeartbeatFormat
+	}
+	_, _ = fmt.Fprintln(p.out, format(p.clean(p.message), roundDuration(p.elapsed())))
+	p.lastPrinted = now
+}
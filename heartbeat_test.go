@@ -0,0 +1,85 @@
+package pin_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestHeartbeatPrintsInNonInteractiveMode(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Building",
+		pin.WithWriter(&buf),
+		pin.WithInterval(5*time.Millisecond),
+		pin.WithHeartbeat(40*time.Millisecond),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(100 * time.Millisecond)
+	p.Stop("Built")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) < 3 {
+		t.Fatalf("Expected heartbeat lines between start and stop, got %q", lines)
+	}
+	if lines[0] != "Building" || lines[len(lines)-1] != "Built" {
+		t.Errorf("Unexpected first or last line in %q", lines)
+	}
+	for _, line := range lines[1 : len(lines)-1] {
+		if !strings.HasPrefix(line, "still running: Building (") || !strings.HasSuffix(line, "ms)") {
+			t.Errorf("Unexpected heartbeat line %q", line)
+		}
+	}
+}
+
+func TestHeartbeatCustomFormatAndRestart(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Building",
+		pin.WithWriter(&buf),
+		pin.WithInterval(5*time.Millisecond),
+		pin.WithHeartbeat(60*time.Millisecond),
+		pin.WithHeartbeatFormat(func(msg string, elapsed time.Duration) string {
+			return fmt.Sprintf("heartbeat %s", msg)
+		}),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+
+	// Printing a line restarts the heartbeat interval.
+	for i := 0; i < 4; i++ {
+		time.Sleep(30 * time.Millisecond)
+		p.UpdateMessage("Linking")
+	}
+	if strings.Contains(buf.String(), "heartbeat") {
+		t.Errorf("Expected no heartbeat while messages are printed, got %q", buf.String())
+	}
+	time.Sleep(100 * time.Millisecond)
+	p.Stop()
+	if !strings.Contains(buf.String(), "heartbeat Linking\n") {
+		t.Errorf("Expected custom heartbeat line, got %q", buf.String())
+	}
+}
+
+func TestHeartbeatIgnoredInInteractiveMode(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Building",
+		pin.WithWriter(&buf),
+		pin.WithInterval(5*time.Millisecond),
+		pin.WithHeartbeat(10*time.Millisecond),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(50 * time.Millisecond)
+	p.Stop()
+	if strings.Contains(buf.String(), "still running") {
+		t.Errorf("Expected no heartbeat in interactive mode, got %q", buf.String())
+	}
+}
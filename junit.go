@@ -0,0 +1,192 @@
+package pin
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// JUnitReport records the outcome of spinners as JUnit XML test cases, for
+// CI dashboards that ingest test reports. Each run of a spinner becomes a
+// test case named after its initial message; spinners are grouped into test
+// suites by prefix. Failed runs, including timeouts, are reported as
+// failures, interrupted runs as errors and canceled runs as skipped.
+// It is safe for concurrent use.
+//
+// Example usage:
+//
+//	report := pin.NewJUnitReport("deploy")
+//	defer report.WriteFile("report.xml")
+//
+//	p := pin.New("Building", pin.WithPrefix("build"), pin.WithJUnitReport(report))
+type JUnitReport struct {
+	name string
+
+	mu    sync.Mutex
+	cases []junitCase
+}
+
+// junitCase is a single recorded run.
+type junitCase struct {
+	suite   string
+	name    string
+	result  result
+	message string
+	started time.Time
+	elapsed time.Duration
+}
+
+// NewJUnitReport creates an empty report. The name is used for the report
+// and for the suite of spinners without a prefix.
+func NewJUnitReport(name string) *JUnitReport {
+	if name == "" {
+		name = "pin"
+	}
+	return &JUnitReport{name: name}
+}
+
+// WithJUnitReport records every run of the spinner in the report.
+func WithJUnitReport(r *JUnitReport) Option {
+	return func(p *Pin) {
+		p.report = r
+	}
+}
+
+// record adds a finished run to the report.
+func (r *JUnitReport) record(c junitCase) {
+	if c.suite == "" {
+		c.suite = r.name
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cases = append(r.cases, c)
+}
+
+// XML representation of a report.
+type (
+	junitTestSuites struct {
+		XMLName  xml.Name         `xml:"testsuites"`
+		Name     string           `xml:"name,attr"`
+		Tests    int              `xml:"tests,attr"`
+		Failures int              `xml:"failures,attr"`
+		Errors   int              `xml:"errors,attr"`
+		Skipped  int              `xml:"skipped,attr"`
+		Time     string           `xml:"time,attr"`
+		Suites   []junitTestSuite `xml:"testsuite"`
+	}
+
+	junitTestSuite struct {
+		Name      string          `xml:"name,attr"`
+		Tests     int             `xml:"tests,attr"`
+		Failures  int             `xml:"failures,attr"`
+		Errors    int             `xml:"errors,attr"`
+		Skipped   int             `xml:"skipped,attr"`
+		Time      string          `xml:"time,attr"`
+		Timestamp string          `xml:"timestamp,attr"`
+		Cases     []junitTestCase `xml:"testcase"`
+
+		elapsed time.Duration
+	}
+
+	junitTestCase struct {
+		Name      string        `xml:"name,attr"`
+		Classname string        `xml:"classname,attr"`
+		Time      string        `xml:"time,attr"`
+		Failure   *junitProblem `xml:"failure,omitempty"`
+		Error     *junitProblem `xml:"error,omitempty"`
+		Skipped   *junitProblem `xml:"skipped,omitempty"`
+	}
+
+	junitProblem struct {
+		Message string `xml:"message,attr,omitempty"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// WriteTo writes the report as JUnit XML to w.
+func (r *JUnitReport) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	doc := r.document()
+	r.mu.Unlock()
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	data = append([]byte(xml.Header), data...)
+	data = append(data, '\n')
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// WriteFile writes the report as JUnit XML to the named file, creating or
+// truncating it.
+func (r *JUnitReport) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := r.WriteTo(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// document builds the XML representation of the recorded runs, keeping
+// suites and test cases in the order they were first recorded.
+// The caller must hold r.mu.
+func (r *JUnitReport) document() *junitTestSuites {
+	doc := &junitTestSuites{Name: r.name}
+	index := make(map[string]int)
+	var total time.Duration
+
+	for _, c := range r.cases {
+		i, ok := index[c.suite]
+		if !ok {
+			i = len(doc.Suites)
+			index[c.suite] = i
+			doc.Suites = append(doc.Suites, junitTestSuite{
+				Name:      c.suite,
+				Timestamp: c.started.UTC().Format("2006-01-02T15:04:05"),
+			})
+		}
+		s := &doc.Suites[i]
+
+		tc := junitTestCase{Name: c.name, Classname: c.suite, Time: junitSeconds(c.elapsed)}
+		switch c.result {
+		case resultFailed:
+			tc.Failure = &junitProblem{Message: c.message, Text: c.message}
+			s.Failures++
+		case resultInterrupted:
+			tc.Error = &junitProblem{Message: c.message, Text: c.message}
+			s.Errors++
+		case resultCanceled:
+			tc.Skipped = &junitProblem{Message: "canceled"}
+			s.Skipped++
+		}
+		s.Cases = append(s.Cases, tc)
+		s.Tests++
+		s.elapsed += c.elapsed
+		total += c.elapsed
+	}
+
+	for i := range doc.Suites {
+		s := &doc.Suites[i]
+		s.Time = junitSeconds(s.elapsed)
+		doc.Tests += s.Tests
+		doc.Failures += s.Failures
+		doc.Errors += s.Errors
+		doc.Skipped += s.Skipped
+	}
+	doc.Time = junitSeconds(total)
+	return doc
+}
+
+// junitSeconds formats d in seconds, as used by JUnit XML.
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
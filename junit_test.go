@@ -0,0 +1,117 @@
+package pin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+type testSuites struct {
+	Tests    int `xml:"tests,attr"`
+	Failures int `xml:"failures,attr"`
+	Errors   int `xml:"errors,attr"`
+	Skipped  int `xml:"skipped,attr"`
+	Suites   []struct {
+		Name  string `xml:"name,attr"`
+		Tests int    `xml:"tests,attr"`
+		Cases []struct {
+			Name    string `xml:"name,attr"`
+			Time    string `xml:"time,attr"`
+			Failure *struct {
+				Message string `xml:"message,attr"`
+			} `xml:"failure"`
+			Error   *struct{} `xml:"error"`
+			Skipped *struct{} `xml:"skipped"`
+		} `xml:"testcase"`
+	} `xml:"testsuite"`
+}
+
+func TestJUnitReport(t *testing.T) {
+	report := pin.NewJUnitReport("deploy")
+	var out bytes.Buffer
+
+	run := func(message, prefix string, finish func(p *pin.Pin, cancel context.CancelFunc)) {
+		p := pin.New(message, pin.WithWriter(&out), pin.WithPrefix(prefix), pin.WithJUnitReport(report))
+		cancel := p.Start(context.Background())
+		defer cancel()
+		time.Sleep(10 * time.Millisecond)
+		finish(p, cancel)
+	}
+	run("Compiling", "build", func(p *pin.Pin, _ context.CancelFunc) {
+		p.UpdateMessage("Linking")
+		p.Stop("Compiled")
+	})
+	run("Testing", "test", func(p *pin.Pin, _ context.CancelFunc) { p.Fail("3 tests failed") })
+	run("Packaging", "build", func(p *pin.Pin, cancel context.CancelFunc) {
+		cancel()
+		waitStopped(t, p)
+	})
+	run("Cleaning", "", func(p *pin.Pin, _ context.CancelFunc) { p.Stop() })
+
+	var buf bytes.Buffer
+	if _, err := report.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("Expected an XML header, got %q", buf.String())
+	}
+
+	var doc testSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid XML: %v\n%s", err, buf.String())
+	}
+	if doc.Tests != 4 || doc.Failures != 1 || doc.Errors != 0 || doc.Skipped != 1 {
+		t.Errorf("Unexpected totals in %s", buf.String())
+	}
+	if len(doc.Suites) != 3 {
+		t.Fatalf("Expected 3 suites, got %s", buf.String())
+	}
+
+	build, test, other := doc.Suites[0], doc.Suites[1], doc.Suites[2]
+	if build.Name != "build" || build.Tests != 2 || build.Cases[0].Name != "Compiling" || build.Cases[1].Skipped == nil {
+		t.Errorf("Unexpected build suite in %s", buf.String())
+	}
+	if build.Cases[0].Time == "0.000" {
+		t.Errorf("Expected a duration, got %q", build.Cases[0].Time)
+	}
+	if test.Name != "test" || test.Cases[0].Failure == nil || test.Cases[0].Failure.Message != "3 tests failed" {
+		t.Errorf("Unexpected test suite in %s", buf.String())
+	}
+	if other.Name != "deploy" || other.Cases[0].Name != "Cleaning" {
+		t.Errorf("Expected unprefixed spinners in the report's suite, got %s", buf.String())
+	}
+}
+
+func TestJUnitReportWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	report := pin.NewJUnitReport("")
+	p := pin.New("Working", pin.WithWriter(&bytes.Buffer{}), pin.WithJUnitReport(report))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop()
+
+	path := filepath.Join(dir, "report.xml")
+	if err := report.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `<testcase name="Working" classname="pin"`) {
+		t.Errorf("Unexpected report: %s", data)
+	}
+}
@@ -0,0 +1,128 @@
+package pin
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// linkParam marks the OSC 8 hyperlinks created by Link. It contains a random
+// token so that untrusted text cannot forge links that survive Sanitize.
+var linkParam = "pin=" + linkToken()
+
+// linkToken returns a random token identifying this process.
+func linkToken() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Link returns text as a hyperlink to url, for use in messages. Terminals
+// that support OSC 8 hyperlinks show text as a clickable link; in
+// non-interactive mode, and on terminals known not to support them, the
+// link is printed as "text (url)". The URL takes no room when messages are
+// truncated.
+//
+// Example usage:
+//
+//	p.Stop("Deployed to " + pin.Link("staging", "https://staging.example.com"))
+func Link(text, url string) string {
+	return "\033]8;" + linkParam + ";" + escapeURL(url) + "\033\\" + text + "\033]8;;\033\\"
+}
+
+// escapeURL percent-encodes the bytes of url that may not appear in an
+// escape sequence.
+func escapeURL(url string) string {
+	var b strings.Builder
+	for i := 0; i < len(url); i++ {
+		if c := url[i]; c <= ' ' || c >= 0x7f {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// parseLink parses an OSC 8 sequence. It returns the URL, which is empty
+// for the sequence ending a link, and whether seq was created by Link.
+func parseLink(seq string) (url string, ok bool) {
+	if !strings.HasPrefix(seq, "\033]8;") {
+		return "", false
+	}
+	body := strings.TrimPrefix(seq, "\033]8;")
+	switch {
+	case strings.HasSuffix(body, "\033\\"):
+		body = strings.TrimSuffix(body, "\033\\")
+	case strings.HasSuffix(body, "\a"):
+		body = strings.TrimSuffix(body, "\a")
+	default:
+		return "", false
+	}
+	i := strings.IndexByte(body, ';')
+	if i < 0 {
+		return "", false
+	}
+	params, url := body[:i], body[i+1:]
+	if params == "" && url == "" {
+		return "", true
+	}
+	for _, p := range strings.Split(params, ":") {
+		if p == linkParam {
+			return url, url != ""
+		}
+	}
+	return "", false
+}
+
+// hyperlinksSupported reports whether the terminal is expected to handle
+// OSC 8 sequences.
+func hyperlinksSupported() bool {
+	switch os.Getenv("TERM") {
+	case "dumb", "linux":
+		return false
+	}
+	return true
+}
+
+// plainLinks replaces the hyperlinks created by Link with "text (url)", or
+// just the URL if the text is the URL itself.
+func plainLinks(s string) string {
+	if !strings.Contains(s, "\033]8;") {
+		return s
+	}
+	var b strings.Builder
+	url, start := "", 0
+	for i := 0; i < len(s); {
+		n := escapeLen(s[i:])
+		if n == 0 {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		seq := s[i : i+n]
+		i += n
+		u, ok := parseLink(seq)
+		switch {
+		case !ok:
+			b.WriteString(seq)
+		case u != "":
+			url, start = u, b.Len()
+		case url != "":
+			if text := b.String()[start:]; text != url {
+				b.WriteString(" (" + url + ")")
+			}
+			url = ""
+		}
+	}
+	if url != "" {
+		b.WriteString(" (" + url + ")")
+	}
+	return b.String()
+}
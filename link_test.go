@@ -0,0 +1,87 @@
+package pin_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/yarlson/pin"
+)
+
+func TestLinkRendersOSC8(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+	defer os.Setenv("TERM", os.Getenv("TERM"))
+	_ = os.Setenv("TERM", "xterm-256color")
+
+	link := pin.Link("staging", "https://staging.example.com/a b")
+	if !strings.HasPrefix(link, "\033]8;") || !strings.Contains(link, ";https://staging.example.com/a%20b\033\\staging\033]8;;\033\\") {
+		t.Fatalf("Unexpected link %q", link)
+	}
+
+	var buf bytes.Buffer
+	p := pin.New("Deploying", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("Deployed to " + link)
+
+	if !strings.Contains(buf.String(), "Deployed to "+link) {
+		t.Errorf("Expected the hyperlink to be written, got %q", buf.String())
+	}
+}
+
+func TestLinkFallsBackToPlainText(t *testing.T) {
+	var buf bytes.Buffer
+	p := pin.New("Deploying", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.UpdateMessage("Open " + pin.Link("https://example.com", "https://example.com"))
+	p.Stop("Deployed to " + pin.Link("staging", "https://staging.example.com"))
+
+	want := "Deploying\nOpen https://example.com\nDeployed to staging (https://staging.example.com)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestLinkFallsBackOnUnsupportedTerminal(t *testing.T) {
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+	defer os.Setenv("TERM", os.Getenv("TERM"))
+	_ = os.Setenv("TERM", "linux")
+
+	var buf bytes.Buffer
+	p := pin.New("Deploying", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop(pin.Link("staging", "https://staging.example.com"))
+
+	if !strings.Contains(buf.String(), "staging (https://staging.example.com)") || strings.Contains(buf.String(), "\033]8") {
+		t.Errorf("Expected a plain link, got %q", buf.String())
+	}
+}
+
+func TestForgedLinksAreSanitized(t *testing.T) {
+	forged := "\033]8;;https://evil.example.com\033\\click\033]8;;\033\\"
+	if got := pin.Sanitize(forged); got != "click" {
+		t.Errorf("Expected forged link to be removed, got %q", got)
+	}
+	link := pin.Link("docs", "https://example.com")
+	if got := pin.Sanitize("see " + link); got != "see "+link {
+		t.Errorf("Expected Link to survive Sanitize, got %q", got)
+	}
+}
+
+func TestLinkIsExcludedFromWidth(t *testing.T) {
+	defer os.Setenv("TERM", os.Getenv("TERM"))
+	_ = os.Setenv("TERM", "xterm-256color")
+
+	got := renderFrame(t, "20", "Open "+pin.Link("docs", "https://example.com/a/very/long/path"))
+	if osc := regexp.MustCompile("\x1b]8;[^\x1b]*\x1b\\\\"); osc.ReplaceAllString(got, "") != "* Open docs" {
+		t.Errorf("Expected the URL to take no width, got %q", got)
+	}
+}
@@ -0,0 +1,67 @@
+package pin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LogEntry describes a line of non-interactive output.
+type LogEntry struct {
+	Time    time.Time
+	Prefix  string
+	Status  string // "started", "running", "done", "failed", "interrupted" or "canceled"
+	Message string
+	Elapsed time.Duration
+}
+
+// WithLogFormat sets the function that formats lines in non-interactive
+// mode, replacing the bare messages printed by default. With a log format,
+// Stop, Fail and cancellation always print a final line, using the current
+// message if none is given.
+//
+// Example usage:
+//
+//	p := pin.New("Compiling",
+//	    pin.WithPrefix("build"),
+//	    pin.WithLogFormat(pin.FormatLogLine),
+//	)
+func WithLogFormat(format func(LogEntry) string) Option {
+	return func(p *Pin) {
+		p.logFormat = format
+	}
+}
+
+// FormatLogLine formats an entry as a timestamped line including the prefix,
+// the status and the elapsed time, e.g.
+//
+//	2026-10-16T10:00:00Z [build] done: compiled (12s)
+func FormatLogLine(e LogEntry) string {
+	var b strings.Builder
+	b.WriteString(e.Time.UTC().Format(time.RFC3339))
+	if e.Prefix != "" {
+		fmt.Fprintf(&b, " [%s]", e.Prefix)
+	}
+	fmt.Fprintf(&b, " %s: %s", e.Status, e.Message)
+	if e.Status != "started" {
+		fmt.Fprintf(&b, " (%v)", roundDuration(e.Elapsed))
+	}
+	return b.String()
+}
+
+// printLog prints a line of non-interactive output.
+// The caller must hold p.mu.
+func (p *Pin) printLog(status, message string) {
+	line := p.clean(message)
+	if p.logFormat != nil {
+		line = p.logFormat(LogEntry{
+			Time:    time.Now(),
+			Prefix:  p.clean(p.prefix),
+			Status:  status,
+			Message: line,
+			Elapsed: p.elapsed(),
+		})
+	}
+	_, _ = fmt.Fprintln(p.out, line)
+	p.lastPrinted = time.Now()
+}
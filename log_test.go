@@ -0,0 +1,101 @@
+package pin_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestFormatLogLine(t *testing.T) {
+	ts := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		entry pin.LogEntry
+		want  string
+	}{
+		{
+			pin.LogEntry{Time: ts, Prefix: "build", Status: "done", Message: "compiled", Elapsed: 12300 * time.Millisecond},
+			"2026-10-16T10:00:00Z [build] done: compiled (12s)",
+		},
+		{
+			pin.LogEntry{Time: ts, Status: "started", Message: "compiling"},
+			"2026-10-16T10:00:00Z started: compiling",
+		},
+		{
+			pin.LogEntry{Time: ts.In(time.FixedZone("CEST", 2*60*60)), Status: "failed", Message: "boom", Elapsed: 1500 * time.Microsecond},
+			"2026-10-16T10:00:00Z failed: boom (2ms)",
+		},
+	}
+	for _, tt := range tests {
+		if got := pin.FormatLogLine(tt.entry); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestWithLogFormatNonInteractive(t *testing.T) {
+	var buf syncBuffer
+	var entries []pin.LogEntry
+	p := pin.New("Compiling",
+		pin.WithWriter(&buf),
+		pin.WithPrefix("build"),
+		pin.WithLogFormat(func(e pin.LogEntry) string {
+			entries = append(entries, e)
+			return fmt.Sprintf("[%s] %s: %s", e.Prefix, e.Status, e.Message)
+		}),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.UpdateMessage("Linking")
+	p.Fail("link error")
+
+	want := "[build] started: Compiling\n[build] running: Linking\n[build] failed: link error\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Expected output %q, got %q", want, got)
+	}
+	if len(entries) != 3 || entries[2].Elapsed <= 0 || entries[2].Time.IsZero() {
+		t.Errorf("Expected entries with time and elapsed time, got %+v", entries)
+	}
+}
+
+func TestWithLogFormatPrintsFinalLineWithoutMessage(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Compiling", pin.WithWriter(&buf), pin.WithLogFormat(pin.FormatLogLine))
+	cancel := p.Start(context.Background())
+	p.Stop()
+	cancel()
+
+	q := pin.New("Testing", pin.WithWriter(&buf), pin.WithLogFormat(pin.FormatLogLine))
+	cancel = q.Start(context.Background())
+	cancel()
+	waitStopped(t, q)
+
+	output := buf.String()
+	if !strings.Contains(output, " done: Compiling (") {
+		t.Errorf("Expected a done line with the current message, got %q", output)
+	}
+	if !strings.Contains(output, " canceled: Testing (") {
+		t.Errorf("Expected a canceled line, got %q", output)
+	}
+}
+
+func TestWithLogFormatHeartbeat(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Compiling",
+		pin.WithWriter(&buf),
+		pin.WithInterval(5*time.Millisecond),
+		pin.WithHeartbeat(20*time.Millisecond),
+		pin.WithLogFormat(func(e pin.LogEntry) string { return e.Status + ": " + e.Message }),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(50 * time.Millisecond)
+	p.Stop()
+
+	if !strings.Contains(buf.String(), "running: Compiling\n") {
+		t.Errorf("Expected heartbeat through the log format, got %q", buf.String())
+	}
+}
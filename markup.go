@@ -0,0 +1,122 @@
+package pin
+
+import (
+	"os"
+	"strings"
+)
+
+// styles maps the text styles available in markup to their SGR sequences.
+var styles = map[string]string{
+	"bold":          "\033[1m",
+	"dim":           "\033[2m",
+	"italic":        "\033[3m",
+	"underline":     "\033[4m",
+	"reverse":       "\033[7m",
+	"strikethrough": "\033[9m",
+}
+
+// WithMarkup enables inline markup in messages and prefixes. A tag such as
+// [cyan], [#ff8700] or [bold] styles the text up to the matching [/]; tags
+// may combine several names, e.g. [bold red], and nest. Text in brackets
+// that is not a known tag, such as [1/3], is printed as is; [[ prints a
+// literal bracket. The markup is removed in non-interactive mode and when
+// the NO_COLOR environment variable is set.
+//
+// Example usage:
+//
+//	p := pin.New("Deploying [cyan]v1.2.3[/] to [bold]prod[/]", pin.WithMarkup())
+func WithMarkup() Option {
+	return func(p *Pin) {
+		p.markup = true
+	}
+}
+
+// styled sanitizes s and renders its markup, if enabled, restoring the base
+// color when a tag is closed. Hyperlinks are spelled out if the terminal
+// does not support them. The caller must hold p.mu.
+func (p *Pin) styled(s string, base Color) string {
+	s = p.sanitize(s)
+	if p.markup {
+		s = renderMarkup(s, base, p.interactive && os.Getenv("NO_COLOR") == "")
+	}
+	if !p.interactive || !hyperlinksSupported() {
+		s = plainLinks(s)
+	}
+	return s
+}
+
+// renderMarkup replaces the markup tags in s with SGR sequences, or removes
+// them if color is false.
+func renderMarkup(s string, base Color, color bool) string {
+	var b strings.Builder
+	var stack []string
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			b.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		if s[i] != '[' {
+			j := strings.IndexAny(s[i+1:], "[\033") + 1
+			if j == 0 {
+				j = len(s) - i
+			}
+			b.WriteString(s[i : i+j])
+			i += j
+			continue
+		}
+		if strings.HasPrefix(s[i:], "[[") {
+			b.WriteByte('[')
+			i += 2
+			continue
+		}
+
+		end := strings.IndexByte(s[i:], ']')
+		if end < 0 {
+			b.WriteString(s[i:])
+			break
+		}
+		tag := s[i+1 : i+end]
+		if tag == "/" && len(stack) > 0 {
+			stack = stack[:len(stack)-1]
+			if color {
+				b.WriteString(ColorReset.String() + base.String() + strings.Join(stack, ""))
+			}
+			i += end + 1
+			continue
+		}
+		if seq, ok := parseTag(tag); ok {
+			stack = append(stack, seq)
+			if color {
+				b.WriteString(seq)
+			}
+			i += end + 1
+			continue
+		}
+		b.WriteByte('[')
+		i++
+	}
+	return b.String()
+}
+
+// parseTag returns the SGR sequences for a markup tag made of style and
+// color names separated by spaces. It returns false if tag is not valid.
+func parseTag(tag string) (string, bool) {
+	names := strings.Fields(tag)
+	if len(names) == 0 {
+		return "", false
+	}
+	var seq string
+	for _, name := range names {
+		if style, ok := styles[strings.ToLower(name)]; ok {
+			seq += style
+			continue
+		}
+		c, err := ParseColor(name)
+		if err != nil {
+			return "", false
+		}
+		seq += c.String()
+	}
+	return seq, true
+}
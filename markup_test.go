@@ -0,0 +1,111 @@
+package pin_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+// finalLine returns the final message printed by an interactive spinner.
+func finalLine(out string) string {
+	return out[strings.LastIndex(out, "\r\033[K")+len("\r\033[K"):]
+}
+
+func TestMarkupRendersSGR(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"Deploying [cyan]v1.2.3[/] to [bold]prod[/]", "Deploying \033[36mv1.2.3\033[0m\033[32m to \033[1mprod\033[0m\033[32m"},
+		{"[bold red]failed[/] ok", "\033[1m\033[31mfailed\033[0m\033[32m ok"},
+		{"[bold]a [#ff8700]b[/] c[/]", "\033[1ma \033[38;2;255;135;0mb\033[0m\033[32m\033[1m c\033[0m\033[32m"},
+		{"[1/3] step [[red] [unknown]", "[1/3] step [red] [unknown]"},
+		{"stray [/] and [bold]unclosed", "stray [/] and \033[1munclosed"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		p := pin.New("Working", pin.WithWriter(&buf), pin.WithMarkup(), pin.WithTextColor(pin.ColorGreen))
+		cancel := p.Start(context.Background())
+		p.Stop(tt.message)
+		cancel()
+
+		want := "\033[32m✓\033[0m \033[32m" + tt.want + "\033[0m\n"
+		if got := finalLine(buf.String()); got != want {
+			t.Errorf("Message %q: expected %q, got %q", tt.message, want, got)
+		}
+	}
+}
+
+func TestMarkupIsPlainInNonInteractiveMode(t *testing.T) {
+	var buf bytes.Buffer
+	p := pin.New("Deploying [cyan]v1.2.3[/]", pin.WithWriter(&buf), pin.WithMarkup(), pin.WithPrefix("[bold]app[/]"),
+		pin.WithLogFormat(func(e pin.LogEntry) string { return e.Prefix + ": " + e.Message }))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("Deployed [green]v1.2.3[/]")
+
+	if got, want := buf.String(), "app: Deploying v1.2.3\napp: Deployed v1.2.3\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestMarkupHonorsNoColor(t *testing.T) {
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+	_ = os.Setenv("NO_COLOR", "1")
+	defer os.Unsetenv("NO_COLOR")
+
+	var buf bytes.Buffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithMarkup())
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("Deployed [cyan]v1.2.3[/]")
+
+	if got := finalLine(buf.String()); !strings.Contains(got, "Deployed v1.2.3") {
+		t.Errorf("Expected plain text, got %q", got)
+	}
+}
+
+func TestMarkupWithoutOptionIsLiteral(t *testing.T) {
+	var buf bytes.Buffer
+	p := pin.New("Working", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("[cyan]v1[/]")
+
+	if got := buf.String(); got != "Working\n[cyan]v1[/]\n" {
+		t.Errorf("Expected the markup as given, got %q", got)
+	}
+}
+
+func TestMarkupIsMeasuredForTruncation(t *testing.T) {
+	got := renderFrame(t, "16", "Deploying [cyan]v1.2.3[/] to prod", pin.WithMarkup())
+	if got != "* Deploying v1…" {
+		t.Errorf("Expected markup to take no width, got %q", got)
+	}
+}
+
+func TestMarkupInSpinnerFrame(t *testing.T) {
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Deploying [cyan]v1[/]", pin.WithWriter(&buf), pin.WithMarkup(), pin.WithInterval(5*time.Millisecond))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(30 * time.Millisecond)
+	p.Stop()
+
+	if !strings.Contains(buf.String(), "Deploying \033[36mv1\033[0m") {
+		t.Errorf("Expected styled frame, got %q", buf.String())
+	}
+}
@@ -0,0 +1,98 @@
+package pin
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// NotifyMethod selects how a finished spinner notifies the user. Methods
+// can be combined, e.g. NotifyBell | NotifyOSC777.
+type NotifyMethod int
+
+const (
+	// NotifyBell rings the terminal bell, which most terminals turn into
+	// an urgency hint or a tab badge.
+	NotifyBell NotifyMethod = 1 << iota
+	// NotifyOSC9 sends a desktop notification with OSC 9, as supported by
+	// iTerm2 and WezTerm.
+	NotifyOSC9
+	// NotifyOSC777 sends a desktop notification with OSC 777, as supported
+	// by VTE-based terminals such as GNOME Terminal, foot and WezTerm.
+	NotifyOSC777
+)
+
+// WithNotifyOnFinish notifies the user when a spinner that ran for at least
+// minDuration is stopped or fails, so that a long build can finish in a
+// background window without being missed. The notification carries the
+// final message and whether the task succeeded. By default, pin rings the
+// bell and sends a desktop notification if the terminal is known to support
+// one; use WithNotifyMethod to choose. It has no effect in non-interactive
+// mode.
+//
+// Example usage:
+//
+//	p := pin.New("Building", pin.WithNotifyOnFinish(time.Minute))
+func WithNotifyOnFinish(minDuration time.Duration) Option {
+	return func(p *Pin) {
+		p.notifyAfter = minDuration
+		p.notify = true
+	}
+}
+
+// WithNotifyMethod sets how WithNotifyOnFinish notifies the user.
+func WithNotifyMethod(m NotifyMethod) Option {
+	return func(p *Pin) {
+		p.notifyMethod = m
+	}
+}
+
+// detectNotifyMethod returns the bell, combined with the desktop
+// notification sequence supported by the terminal, if it is known.
+func detectNotifyMethod() NotifyMethod {
+	switch {
+	case os.Getenv("TERM_PROGRAM") == "iTerm.app":
+		return NotifyBell | NotifyOSC9
+	case os.Getenv("TERM_PROGRAM") == "WezTerm",
+		os.Getenv("VTE_VERSION") != "",
+		strings.HasPrefix(os.Getenv("TERM"), "foot"):
+		return NotifyBell | NotifyOSC777
+	}
+	return NotifyBell
+}
+
+// notifyFinish notifies the user that a long run finished with res.
+// The caller must hold p.mu.
+func (p *Pin) notifyFinish(res result, message string) {
+	if !p.notify || !p.interactive || p.elapsed() < p.notifyAfter {
+		return
+	}
+	if res != resultDone && res != resultFailed {
+		return
+	}
+
+	title := "Done"
+	if res == resultFailed {
+		title = "Failed"
+	}
+	if p.prefix != "" {
+		title = p.clean(p.prefix) + ": " + title
+	}
+	title = strings.Replace(strings.Replace(title, "\n", " ", -1), ";", ",", -1)
+	body := fmt.Sprintf("%s (%v)", strings.Replace(p.clean(message), "\n", " ", -1), roundDuration(p.elapsed()))
+
+	method := p.notifyMethod
+	if method == 0 {
+		method = detectNotifyMethod()
+	}
+	if method&NotifyOSC9 != 0 {
+		_, _ = fmt.Fprintf(p.out, "\033]9;%s: %s\a", title, body)
+	}
+	if method&NotifyOSC777 != 0 {
+		_, _ = fmt.Fprintf(p.out, "\033]777;notify;%s;%s\a", title, body)
+	}
+	if method&NotifyBell != 0 {
+		_, _ = fmt.Fprint(p.out, "\a")
+	}
+}
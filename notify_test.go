@@ -0,0 +1,93 @@
+package pin_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestNotifyOnFinish(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf bytes.Buffer
+	p := pin.New("Building",
+		pin.WithWriter(&buf),
+		pin.WithPrefix("app"),
+		pin.WithNotifyOnFinish(20*time.Millisecond),
+		pin.WithNotifyMethod(pin.NotifyBell|pin.NotifyOSC9|pin.NotifyOSC777),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(30 * time.Millisecond)
+	p.Fail("3 tests failed")
+
+	output := buf.String()
+	for _, want := range []string{
+		"\033]9;app: Failed: 3 tests failed (",
+		"\033]777;notify;app: Failed;3 tests failed (",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in %q", want, output)
+		}
+	}
+	if !strings.HasSuffix(output, "\a\a") {
+		t.Errorf("Expected the bell after the notifications, got %q", output)
+	}
+}
+
+func TestNotifySkipsShortRuns(t *testing.T) {
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf bytes.Buffer
+	p := pin.New("Building", pin.WithWriter(&buf), pin.WithNotifyOnFinish(time.Minute))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("Built")
+
+	if strings.Contains(buf.String(), "\a") {
+		t.Errorf("Expected no notification, got %q", buf.String())
+	}
+}
+
+func TestNotifySkipsCancellation(t *testing.T) {
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Building", pin.WithWriter(&buf), pin.WithNotifyOnFinish(0))
+	cancel := p.Start(context.Background())
+	cancel()
+	waitStopped(t, p)
+
+	if strings.Contains(buf.String(), "\a") {
+		t.Errorf("Expected no notification, got %q", buf.String())
+	}
+}
+
+func TestNotifyDetectsTerminal(t *testing.T) {
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+	defer os.Setenv("TERM_PROGRAM", os.Getenv("TERM_PROGRAM"))
+	defer os.Setenv("VTE_VERSION", os.Getenv("VTE_VERSION"))
+	_ = os.Unsetenv("TERM_PROGRAM")
+	_ = os.Setenv("VTE_VERSION", "7200")
+
+	var buf bytes.Buffer
+	p := pin.New("Building", pin.WithWriter(&buf), pin.WithNotifyOnFinish(0))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop()
+
+	output := buf.String()
+	if !strings.Contains(output, "\033]777;notify;Done;Building (") || !strings.HasSuffix(output, "\a") || strings.Contains(output, "\033]9;") {
+		t.Errorf("Expected an OSC 777 notification and the bell, got %q", output)
+	}
+}
@@ -0,0 +1,107 @@
+package pin
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithExcludePausedTime makes Elapsed leave out the time the spinner spent paused.
+func WithExcludePausedTime() Option {
+	return func(p *Pin) {
+		p.excludePaused = true
+	}
+}
+
+// Pause clears the spinner line, shows the cursor if it was hidden and
+// suspends rendering, e.g. to ask the user a question in the middle of a
+// task. The message can still be updated while paused. Pause has no effect
+// unless the spinner is running.
+func (p *Pin) Pause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.state != StateRunning {
+		return
+	}
+
+	p.state = StatePaused
+	p.pausedAt = time.Now()
+	if p.interactive {
+		p.clearLine()
+	}
+	p.updateProgress(progressPaused)
+	if p.cursorHidden {
+		_, _ = fmt.Fprint(p.out, escShowCursor)
+	}
+}
+
+// Resume redraws the spinner and continues rendering after Pause.
+// Resume has no effect unless the spinner is paused.
+func (p *Pin) Resume() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.state != StatePaused {
+		return
+	}
+
+	p.state = StateRunning
+	p.pausedFor += time.Since(p.pausedAt)
+	p.touch()
+	if p.cursorHidden {
+		_, _ = fmt.Fprint(p.out, escHideCursor)
+	}
+	p.updateProgress(progressNormal)
+	if p.interactive {
+		p.draw()
+	}
+}
+
+// Suspend pauses the spinner while fn runs and resumes it afterwards.
+// If fn panics, the spinner is stopped, restoring the terminal, before the
+// panic continues.
+//
+// Example usage:
+//
+//	p.Suspend(func() {
+//	    fmt.Print("Overwrite existing files? [y/N] ")
+//	    answer, _ = reader.ReadString('\n')
+//	})
+func (p *Pin) Suspend(fn func()) {
+	p.Pause()
+	defer func() {
+		if r := recover(); r != nil {
+			p.Stop()
+			panic(r)
+		}
+		p.Resume()
+	}()
+	fn()
+}
+
+// Elapsed returns how long the spinner has been running, or how long its
+// last run took if it is finished. Time spent paused is included unless
+// WithExcludePausedTime is set.
+func (p *Pin) Elapsed() time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.elapsed()
+}
+
+// elapsed returns the duration of the current or last run.
+// The caller must hold p.mu.
+func (p *Pin) elapsed() time.Duration {
+	if p.started.IsZero() {
+		return 0
+	}
+	end := p.ended
+	if p.active() {
+		end = time.Now()
+	}
+	d := end.Sub(p.started)
+	if p.excludePaused {
+		d -= p.pausedFor
+		if p.state == StatePaused {
+			d -= end.Sub(p.pausedAt)
+		}
+	}
+	return d
+}
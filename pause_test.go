@@ -0,0 +1,126 @@
+package pin_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestPauseSuspendsRendering(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithInterval(5*time.Millisecond))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(30 * time.Millisecond)
+
+	p.Pause()
+	if p.State() != pin.StatePaused {
+		t.Fatalf("Expected paused state, got %v", p.State())
+	}
+	if !strings.HasSuffix(buf.String(), "\r\033[K") {
+		t.Errorf("Expected Pause to clear the line, got %q", buf.String())
+	}
+	before := buf.String()
+	time.Sleep(30 * time.Millisecond)
+	if buf.String() != before {
+		t.Errorf("Expected no output while paused, got %q", strings.TrimPrefix(buf.String(), before))
+	}
+
+	p.UpdateMessage("Resumed")
+	p.Resume()
+	if p.State() != pin.StateRunning {
+		t.Fatalf("Expected running state, got %v", p.State())
+	}
+	if !strings.Contains(strings.TrimPrefix(buf.String(), before), "Resumed") {
+		t.Errorf("Expected Resume to redraw the spinner, got %q", buf.String())
+	}
+	p.Stop("Done")
+}
+
+func TestSuspendStopsAfterPanic(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+
+	var state pin.State
+	func() {
+		defer func() { _ = recover() }()
+		p.Suspend(func() {
+			state = p.State()
+			panic("prompt failed")
+		})
+	}()
+	if state != pin.StatePaused {
+		t.Errorf("Expected spinner to be paused inside Suspend, got %v", state)
+	}
+	if p.State() != pin.StateFinished {
+		t.Errorf("Expected spinner to be stopped after a panic in Suspend, got %v", p.State())
+	}
+}
+
+func TestSuspendResumes(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+
+	p.Suspend(func() {})
+	if p.State() != pin.StateRunning {
+		t.Errorf("Expected spinner to be running after Suspend, got %v", p.State())
+	}
+	p.Stop()
+}
+
+func TestStopWhilePaused(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Pause()
+	p.Stop("Done")
+	if p.State() != pin.StateFinished {
+		t.Errorf("Expected finished state, got %v", p.State())
+	}
+	if !strings.Contains(buf.String(), "Done") {
+		t.Errorf("Expected final message, got %q", buf.String())
+	}
+}
+
+func TestElapsedExcludesPausedTime(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithExcludePausedTime())
+	if p.Elapsed() != 0 {
+		t.Fatalf("Expected zero elapsed time before Start, got %v", p.Elapsed())
+	}
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Pause()
+	time.Sleep(100 * time.Millisecond)
+	p.Resume()
+	p.Stop()
+	if d := p.Elapsed(); d >= 100*time.Millisecond {
+		t.Errorf("Expected paused time to be excluded, got %v", d)
+	}
+
+	q := pin.New("Working", pin.WithWriter(&buf))
+	cancel = q.Start(context.Background())
+	defer cancel()
+	q.Pause()
+	time.Sleep(100 * time.Millisecond)
+	q.Stop()
+	if d := q.Elapsed(); d < 100*time.Millisecond {
+		t.Errorf("Expected paused time to be included by default, got %v", d)
+	}
+}
@@ -63,8 +63,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 )
 
@@ -197,6 +197,14 @@ func WithSpinnerFrames(frames []rune) Option {
 	}
 }
 
+// WithInterval sets the delay between spinner frames.
+// If not set, defaults to 100ms.
+func WithInterval(d time.Duration) Option {
+	return func(p *Pin) {
+		p.interval = d
+	}
+}
+
 // WithWriter sets a custom io.Writer for spinner output.
 func WithWriter(w io.Writer) Option {
 	return func(p *Pin) {
@@ -210,11 +218,11 @@ func WithWriter(w io.Writer) Option {
 // Basic usage:
 //
 //	p := pin.New("Loading")
-//	p.Start()
+//	p.Start(context.Background())
 //	time.Sleep(2 * time.Second)
 //	p.Stop("Done")
 //
-// Advanced usage:
+// Advanced usage (setters are safe to call while the spinner is running):
 //
 //	p := pin.New("Processing")
 //	p.SetPrefix("Status")
@@ -222,7 +230,7 @@ func WithWriter(w io.Writer) Option {
 //	p.SetSeparatorColor(pin.ColorWhite)
 //	p.SetSpinnerColor(pin.ColorCyan)
 //	p.SetTextColor(pin.ColorYellow)
-//	p.Start()
+//	p.Start(context.Background())
 //
 //	// Update message during operation
 //	p.UpdateMessage("Still working...")
@@ -237,16 +245,26 @@ func WithWriter(w io.Writer) Option {
 //	    WithFailSymbol('✖'),
 //	    WithFailSymbolColor(ColorRed),
 //	)
-//	p.Start()
+//	p.Start(context.Background())
 //	// ... error occurred ...
 //	p.Fail("Deployment failed")
 type Pin struct {
 	frames          []rune
+	interval        time.Duration
 	current         int
 	message         string
-	messageMu       sync.RWMutex
-	stopChan        chan struct{}
-	isRunning       int32
+	mu              sync.RWMutex // guards message, the configuration and the lifecycle fields
+	state           State
+	interactive     bool
+	runCtx          context.Context
+	cancel          context.CancelFunc
+	done            chan struct{} // closed when the render goroutine of the current run exits
+	finishing       chan struct{} // closed when a finished run has printed its final message
+	started         time.Time
+	ended           time.Time
+	pausedAt        time.Time
+	pausedFor       time.Duration
+	excludePaused   bool
 	spinnerColor    Color
 	textColor       Color
 	doneSymbol      rune
@@ -259,21 +277,108 @@ type Pin struct {
 	separator       string
 	separatorColor  Color
 	position        Position
+	truncation      Truncation
+	raw             bool // write messages without sanitizing them
+	markup          bool
+	width           int    // terminal width in columns, 0 if unknown
+	widthGen        uint64 // value of resizes when width was queried
+	drawn           []int  // widths of the spinner lines on screen
 	out             io.Writer
-	wg              sync.WaitGroup
+	hideCursor      bool
+	cursorHidden    bool
+
+	signals              []os.Signal
+	appSignals           bool
+	interruptSymbol      rune
+	interruptSymbolColor Color
+	interruptMessage     string
+	onInterrupt          func(os.Signal)
+
+	timeout    time.Duration
+	timeoutMsg string
+
+	stallAfter    time.Duration
+	slowAfter     time.Duration
+	warningColor  Color
+	warningSymbol rune
+	stallNotice   bool
+	onWarning     func(Warning)
+	lastActivity  time.Time
+	stalled       bool
+	slow          bool
+
+	heartbeatEvery  time.Duration
+	heartbeatFormat func(message string, elapsed time.Duration) string
+	lastPrinted     time.Time
+	logFormat       func(LogEntry) string
+
+	ci        CIProvider
+	ciSection string
+
+	report *JUnitReport
+	task   string // message at the start of the run, used to name it in reports
+
+	title     bool
+	taskbar   bool
+	progress  int // completed percentage, -1 if unknown
+	titleSet  bool
+	lastTitle string
+
+	notify       bool
+	notifyAfter  time.Duration
+	notifyMethod NotifyMethod
+
+	bar   *StatusBar
+	inBar bool // the current run is drawn on a status bar line
+
+	noop bool // set for the spinner returned by FromContext when none is attached
 }
 
 var defaultFrames = []rune{
 	'⠋', '⠙', '⠹', '⠸', '⠼', '⠴', '⠦', '⠧', '⠇', '⠏',
 }
 
+const defaultInterval = 100 * time.Millisecond
+
 // New creates a new Pin instance with the given message and optional configuration options.
-// It sets default styling and applies any provided options.
+// It sets default styling, applies the package-level defaults set by SetDefaults
+// and then any provided options. Invalid settings, such as empty frames or a nil
+// writer, are replaced with their defaults; use NewE to have them reported instead.
 func New(message string, opts ...Option) *Pin {
-	p := &Pin{
+	p := newPin(message, opts...)
+	_ = p.checkConfig(true)
+	return p
+}
+
+// NewE is like New but returns an error describing the first invalid setting
+// instead of falling back to the default.
+func NewE(message string, opts ...Option) (*Pin, error) {
+	p := newPin(message, opts...)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// newPin creates a Pin with default styling and applies the package-level
+// defaults and the given options without validating the result.
+func newPin(message string, opts ...Option) *Pin {
+	p := basePin(message)
+	for _, opt := range Defaults() {
+		opt(p)
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// basePin creates a Pin with the built-in default styling.
+func basePin(message string) *Pin {
+	return &Pin{
 		frames:          defaultFrames,
+		interval:        defaultInterval,
 		message:         message,
-		stopChan:        make(chan struct{}, 1),
 		spinnerColor:    ColorDefault,
 		textColor:       ColorDefault,
 		doneSymbol:      '✓',
@@ -287,146 +392,262 @@ func New(message string, opts ...Option) *Pin {
 		separatorColor:  ColorWhite,
 		position:        PositionLeft,
 		out:             os.Stdout,
+
+		interruptSymbol:      '⚠',
+		interruptSymbolColor: ColorYellow,
+		interruptMessage:     "Interrupted",
+
+		warningColor: ColorYellow,
+
+		ci:       DetectCI(),
+		progress: -1,
 	}
-	for _, opt := range opts {
-		opt(p)
-	}
-	return p
 }
 
 // Start begins the spinner animation using the provided context.
 // It returns a cancel function which, when called, will stop the spinner.
 // Note: Canceling the returned function stops the spinner without printing
 // a final message. To print a final message, use the Stop() method.
+//
+// A spinner that has been stopped, failed or canceled can be started again.
+// Calling Start on a running spinner is a no-op.
 func (p *Pin) Start(ctx context.Context) context.CancelFunc {
-	if p.IsRunning() {
+	if p.noop {
 		return func() {}
 	}
 
-	if !isTerminal(p.out) {
-		ctx, cancel := context.WithCancel(ctx)
-		p.setRunning(true)
-		p.messageMu.RLock()
-		msg := p.message
-		p.messageMu.RUnlock()
-		_, _ = fmt.Fprintln(p.out, msg)
-		go func() {
-			<-ctx.Done()
-			p.setRunning(false)
-		}()
-		return cancel
-	}
-
-	p.setRunning(true)
-
-	ctx, cancel := context.WithCancel(ctx)
-	ticker := time.NewTicker(100 * time.Millisecond)
-	p.wg.Add(1)
-	go func() {
-		defer ticker.Stop()
-		defer p.wg.Done()
-		for {
-			select {
-			case <-p.stopChan:
-				return
-			case <-ctx.Done():
-				p.setRunning(false)
-				_, _ = fmt.Fprint(p.out, "\r\033[K")
-				return
-			case <-ticker.C:
-				prefixPart := p.buildPrefixPart()
-
-				p.messageMu.RLock()
-				message := p.message
-				p.messageMu.RUnlock()
-
-				var format string
-				var args []interface{}
-
-				if p.position == PositionLeft {
-					format = "\r\033[K%s%s%c%s %s%s%s"
-					args = []interface{}{
-						prefixPart,
-						p.spinnerColor, p.frames[p.current], ColorReset,
-						p.textColor, message, ColorReset,
-					}
-				} else {
-					format = "\r\033[K%s%s%s%s %s%c%s "
-					args = []interface{}{
-						prefixPart,
-						p.textColor, message, ColorReset,
-						p.textColor, p.frames[p.current], ColorReset,
-					}
-				}
-
-				_, _ = fmt.Fprintf(p.out, format, args...)
-				p.current = (p.current + 1) % len(p.frames)
+	p.mu.Lock()
+	for p.active() || p.finishing != nil {
+		wait := p.finishing
+		if wait == nil {
+			if p.runCtx.Err() == nil {
+				p.mu.Unlock()
+				return func() {}
 			}
+			// The previous run was canceled but has not wound down yet.
+			wait = p.done
 		}
-	}()
+		p.mu.Unlock()
+		<-wait
+		p.mu.Lock()
+	}
+
+	var runCtx context.Context
+	var cancel context.CancelFunc
+	if p.timeout > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, p.timeout)
+	} else {
+		runCtx, cancel = context.WithCancel(ctx)
+	}
+	done := make(chan struct{})
+	p.state = StateRunning
+	p.runCtx, p.cancel, p.done = runCtx, cancel, done
+	p.interactive = isTerminal(p.out)
+	p.current = 0
+	p.started, p.ended, p.pausedFor = time.Now(), time.Time{}, 0
+	p.lastActivity, p.stalled, p.slow = p.started, false, false
+	p.lastPrinted = p.started
+	p.task = p.message
+	p.resetWidth()
+	p.inBar = p.bar != nil && p.interactive && p.bar.attach(p)
+	interactive, interval := p.interactive, p.interval
+	if !interactive {
+		p.ciStart()
+		p.printLog("started", p.message)
+	}
+	hidden := p.hideCursorLocked()
+	p.startTitle()
+	p.updateProgress(progressNormal)
+	restore, handled := hidden || p.inBar, p.signals
+	p.mu.Unlock()
+
+	if restore || len(handled) > 0 {
+		signals.add(done, p, restore, handled)
+	}
+	go p.run(runCtx, done, interval)
 
 	return cancel
 }
 
-// Stop halts the spinner animation and optionally displays a final message.
-func (p *Pin) Stop(message ...string) {
-	if !p.IsRunning() {
-		return
+// run draws animation frames until ctx is done. It closes done on return.
+// In non-interactive mode it draws nothing but still ticks, so that
+// time-based checks keep running.
+func (p *Pin) run(ctx context.Context, done chan struct{}, interval time.Duration) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer func() { ticker.Stop() }()
+	for {
+		select {
+		case <-ctx.Done():
+			p.cancelRun(done, ctx.Err())
+			return
+		case <-ticker.C:
+			if next := p.tick(); next != interval {
+				ticker.Stop()
+				interval = next
+				ticker = time.NewTicker(interval)
+			}
+		}
 	}
+}
 
-	if p.handleNonTerminal(message...) {
+// cancelRun finishes a run that ended through its context rather than
+// through Stop or Fail. A canceled run just clears the spinner line, while a
+// run whose deadline expired fails with a timeout message.
+func (p *Pin) cancelRun(done chan struct{}, err error) {
+	p.mu.Lock()
+	if p.done != done || !p.active() {
+		p.mu.Unlock()
 		return
 	}
+	p.markFinished()
+	if err == context.DeadlineExceeded {
+		p.finalize(resultFailed, p.timeoutMessage())
+	} else {
+		p.finalize(resultCanceled)
+	}
+	p.settle()
+	p.mu.Unlock()
 
-	p.setRunning(false)
-	p.stopChan <- struct{}{}
-	p.wg.Wait()
-
-	_, _ = fmt.Fprint(p.out, "\r\033[K")
+	signals.remove(done)
+}
 
-	if len(message) > 0 {
-		p.printResult(message[0], p.doneSymbol, p.doneSymbolColor)
-	}
+// Stop halts the spinner animation and optionally displays a final message.
+func (p *Pin) Stop(message ...string) {
+	p.finish(resultDone, message...)
 }
 
 // Fail halts the spinner animation and displays a failure message.
 // This method is similar to Stop but uses a distinct symbol and color scheme to indicate an error state.
 func (p *Pin) Fail(message ...string) {
-	if !p.IsRunning() {
-		return
+	p.finish(resultFailed, message...)
+}
+
+// result is the outcome of a spinner run.
+type result int
+
+const (
+	resultDone result = iota
+	resultFailed
+	resultInterrupted
+	resultCanceled
+)
+
+// String returns the status word for the result.
+func (r result) String() string {
+	switch r {
+	case resultFailed:
+		return "failed"
+	case resultInterrupted:
+		return "interrupted"
+	case resultCanceled:
+		return "canceled"
+	default:
+		return "done"
 	}
+}
 
-	if p.handleNonTerminal(message...) {
-		return
+// finish halts the spinner and prints the final message, if any, with the
+// symbol for the result. Only the first of concurrent calls prints anything;
+// finish reports whether it was that call.
+func (p *Pin) finish(res result, message ...string) bool {
+	if !p.halt() {
+		return false
 	}
 
-	p.setRunning(false)
-	p.stopChan <- struct{}{}
-	p.wg.Wait()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finalize(res, message...)
+	p.settle()
+	return true
+}
 
-	fmt.Print("\r\033[K")
+// finalize clears the spinner line, prints the final message, if any, and
+// restores the cursor. The caller must hold p.mu.
+func (p *Pin) finalize(res result, message ...string) {
+	msg := p.message
+	if len(message) > 0 {
+		msg = message[0]
+	}
+	if p.report != nil {
+		p.report.record(junitCase{
+			suite:   p.clean(p.prefix),
+			name:    p.clean(p.task),
+			result:  res,
+			message: p.clean(msg),
+			started: p.started,
+			elapsed: p.elapsed(),
+		})
+	}
+
+	if !p.interactive {
+		if len(message) > 0 || p.logFormat != nil {
+			p.printLog(res.String(), msg)
+		}
+		p.ciEnd(res, msg)
+		return
+	}
+
+	p.clearLine()
 
 	if len(message) > 0 {
-		p.printResult(message[0], p.failSymbol, p.failSymbolColor)
+		p.printResult(message[0], res)
+	}
+	if res == resultFailed {
+		p.updateProgress(progressError)
+	} else {
+		p.updateProgress(progressNone)
+	}
+	p.restoreTitle()
+	p.notifyFinish(res, msg)
+	if p.inBar {
+		p.bar.detach(p)
+		p.inBar = false
+	}
+	p.showCursorLocked()
+}
+
+// halt moves an active spinner to the finished state and waits for its
+// render goroutine to exit. It returns false if the spinner was not active.
+func (p *Pin) halt() bool {
+	p.mu.Lock()
+	if !p.active() {
+		p.mu.Unlock()
+		return false
 	}
+	p.markFinished()
+	cancel, done := p.cancel, p.done
+	p.mu.Unlock()
+
+	cancel()
+	<-done
+	signals.remove(done)
+	return true
 }
 
 // UpdateMessage changes the message shown next to the spinner.
 func (p *Pin) UpdateMessage(message string) {
-	if !p.IsRunning() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.active() {
 		return
 	}
 
-	p.messageMu.Lock()
 	p.message = message
-	p.messageMu.Unlock()
-	if !isTerminal(p.out) {
-		_, _ = fmt.Fprintln(p.out, message)
+	p.touch()
+	p.updateTitle()
+	if !p.interactive {
+		p.printLog("running", message)
 	}
 }
 
 // String returns the ANSI color code for the given color
 func (c Color) String() string {
+	if c.isRGB() {
+		r, g, b := c.rgb()
+		return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+	}
 	switch c {
 	case ColorReset:
 		return "\033[0m"
@@ -459,6 +680,10 @@ func isTerminal(w io.Writer) bool {
 		return true
 	}
 
+	if b, ok := w.(*StatusBar); ok {
+		w = b.out
+	}
+
 	// Ensure the writer is an *os.File
 	f, ok := w.(*os.File)
 	if !ok {
@@ -475,23 +700,126 @@ func isTerminal(w io.Writer) bool {
 
 var ForceInteractive bool
 
+// tick checks the time-based warnings and draws the next animation frame
+// unless rendering is suspended. It returns the interval to wait before the
+// next frame.
+func (p *Pin) tick() time.Duration {
+	p.mu.Lock()
+	warnings := p.checkWarnings(time.Now())
+	if p.state == StateRunning && p.interactive {
+		p.draw()
+		p.current = (p.current + 1) % len(p.frames)
+	}
+	p.heartbeat(time.Now())
+	interval, onWarning := p.interval, p.onWarning
+	p.mu.Unlock()
+
+	if onWarning != nil {
+		for _, w := range warnings {
+			go onWarning(w)
+		}
+	}
+	return interval
+}
+
+// draw writes the current animation frame. Continuation lines of a
+// multi-line message are indented under the start of the message.
+// The caller must hold p.mu.
+func (p *Pin) draw() {
+	frame := p.frames[p.current%len(p.frames)]
+	prefixPart := p.buildPrefixPart()
+	message := p.styled(p.message, p.textColor)
+	spinnerColor, rightSpinnerColor := p.spinnerColor, p.textColor
+	if p.stalled || p.slow {
+		frame, spinnerColor, rightSpinnerColor = p.warningFrame(frame), p.warningColor, p.warningColor
+		message += p.stallSuffix()
+	}
+
+	if p.inBar {
+		// A status bar line shows the message on a single line.
+		message = strings.Replace(message, "\n", " ", -1)
+		p.refreshWidth()
+	} else {
+		p.clearLine()
+	}
+	lines := strings.Split(message, "\n")
+	lead := stringWidth(prefixPart) + runeWidth(frame) + 1
+	if p.position == PositionRight {
+		lead = stringWidth(prefixPart)
+	}
+	indent := strings.Repeat(" ", lead)
+
+	var b strings.Builder
+	for i, line := range lines {
+		if p.width > 0 {
+			// Leave the last column free so that the cursor never wraps.
+			used := lead
+			if p.position == PositionRight && i == 0 {
+				used += runeWidth(frame) + 2
+			}
+			line = truncate(line, p.width-1-used, p.truncation)
+		}
+
+		switch {
+		case i > 0:
+			line = fmt.Sprintf("\n%s%s%s%s", indent, p.textColor, line, ColorReset)
+		case p.position == PositionLeft:
+			line = fmt.Sprintf("%s%s%c%s %s%s%s", prefixPart, spinnerColor, frame, ColorReset, p.textColor, line, ColorReset)
+		default:
+			line = fmt.Sprintf("%s%s%s%s %s%c%s ", prefixPart, p.textColor, line, ColorReset, rightSpinnerColor, frame, ColorReset)
+		}
+		b.WriteString(line)
+		p.drawn = append(p.drawn, stringWidth(line))
+	}
+	if p.inBar {
+		p.drawn = p.drawn[:0]
+		if p.bar.render(p, b.String()) {
+			return
+		}
+		p.inBar = false
+	}
+	_, _ = fmt.Fprint(p.out, b.String())
+}
+
+// writer returns the configured output writer.
+func (p *Pin) writer() io.Writer {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.out
+}
+
 // buildPrefixPart constructs the prefix string (including colors) if a prefix is set.
+// The caller must hold p.mu.
 func (p *Pin) buildPrefixPart() string {
 	if p.prefix == "" {
 		return ""
 	}
-	return fmt.Sprintf("%s%s%s %s%s%s ", p.prefixColor, p.prefix, ColorReset, p.separatorColor, p.separator, ColorReset)
+	return fmt.Sprintf("%s%s%s %s%s%s ", p.prefixColor, p.styled(p.prefix, p.prefixColor), ColorReset, p.separatorColor, p.styled(p.separator, p.separatorColor), ColorReset)
 }
 
-// printResult prints the final message along with a symbol using the appropriate formatting.
-func (p *Pin) printResult(msg string, symbol rune, symbolColor Color) {
-	var msgColorCode Color
-	if symbol == p.failSymbol && p.failColor != ColorDefault {
-		msgColorCode = p.failColor
-	} else {
-		msgColorCode = p.textColor
+// printResult prints the final message along with the symbol for the result using the appropriate formatting.
+// The caller must hold p.mu.
+func (p *Pin) printResult(msg string, res result) {
+	symbol, symbolColor := p.doneSymbol, p.doneSymbolColor
+	msgColorCode := p.textColor
+	switch res {
+	case resultFailed:
+		symbol, symbolColor = p.failSymbol, p.failSymbolColor
+		if p.failColor != ColorDefault {
+			msgColorCode = p.failColor
+		}
+	case resultInterrupted:
+		symbol, symbolColor = p.interruptSymbol, p.interruptSymbolColor
 	}
 	prefixPart := p.buildPrefixPart()
+	msg = p.styled(msg, msgColorCode)
+
+	// Indent continuation lines of a multi-line message under its start.
+	lead := stringWidth(prefixPart)
+	if p.position == PositionLeft {
+		lead += runeWidth(symbol) + 1
+	}
+	msg = strings.Replace(msg, "\n", "\n"+strings.Repeat(" ", lead), -1)
 
 	if p.position == PositionLeft {
 		format := "%s%s%c%s %s%s%s\n"
@@ -502,34 +830,17 @@ func (p *Pin) printResult(msg string, symbol rune, symbolColor Color) {
 	}
 }
 
-// handleNonTerminal checks if stdout is non-terminal.
-// If yes, it prints a plain message (if provided) and returns true.
-func (p *Pin) handleNonTerminal(message ...string) bool {
-	if !isTerminal(p.out) {
-		if len(message) > 0 {
-			_, _ = fmt.Fprintln(p.out, message[0])
-		}
-		p.setRunning(false)
-		return true
-	}
-	return false
-}
-
 // Message returns the current spinner message.
 func (p *Pin) Message() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	return p.message
 }
 
-// IsRunning returns whether the spinner is active.
+// IsRunning returns whether the spinner is active, i.e. running or paused,
+// or still printing its final message.
 func (p *Pin) IsRunning() bool {
-	return atomic.LoadInt32(&p.isRunning) == 1
-}
-
-// setRunning sets the running state of the spinner.
-func (p *Pin) setRunning(running bool) {
-	var val int32
-	if running {
-		val = 1
-	}
-	atomic.StoreInt32(&p.isRunning, val)
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.active() || p.finishing != nil
 }
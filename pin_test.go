@@ -524,3 +524,40 @@ func TestFailWhenNotRunning(t *testing.T) {
 		t.Errorf("Expected no output when calling Fail on non-running spinner, got: %q", output)
 	}
 }
+
+// Test that multi-line messages are indented and fully erased on redraw.
+func TestMultiLineMessage(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Building\nstep 1/3\nstep 2/3",
+		pin.WithWriter(&buf),
+		pin.WithPrefix("app"),
+		pin.WithSpinnerFrames([]rune{'*'}),
+		pin.WithInterval(5*time.Millisecond),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(30 * time.Millisecond)
+
+	if got, want := lastFrame(buf.String()), "app › * Building\n        step 1/3\n        step 2/3"; got != want {
+		t.Errorf("Expected frame %q, got %q", want, got)
+	}
+
+	p.UpdateMessage("Linking")
+	time.Sleep(30 * time.Millisecond)
+	p.Stop("Built\ndone in 3 steps")
+
+	output := buf.String()
+	if !strings.Contains(output, "\r\033[K\033[1A\033[K\033[1A\033[Kapp") {
+		t.Errorf("Expected all three lines to be erased, got %q", output)
+	}
+	if !strings.Contains(output, "step 2/3\x1b[0m\r\x1b[K\x1b[1A\x1b[K\x1b[1A\x1b[K") {
+		t.Errorf("Expected no stacked frames, got %q", output)
+	}
+	if got := sgr.ReplaceAllString(output[strings.LastIndex(output, "\r\033[K")+len("\r\033[K"):], ""); got != "app › ✓ Built\n        done in 3 steps\n" {
+		t.Errorf("Expected indented final message, got %q", got)
+	}
+}
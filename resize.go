@@ -0,0 +1,60 @@
+package pin
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// resizes counts the terminal resizes seen by watchResize. Spinners compare
+// it with the value from their last width query to notice a resize.
+var resizes uint64
+
+// resetWidth queries the terminal width at the start of a run.
+// The caller must hold p.mu.
+func (p *Pin) resetWidth() {
+	p.width, p.widthGen, p.drawn = 0, 0, nil
+	if p.interactive {
+		watchResize()
+		p.width, p.widthGen = terminalWidth(p.out), atomic.LoadUint64(&resizes)
+	}
+}
+
+// refreshWidth queries the terminal width again after a resize. It returns
+// the number of rows the drawn lines occupy in the current width, which is
+// more than one line each if the terminal shrank and wrapped them.
+// The caller must hold p.mu.
+func (p *Pin) refreshWidth() int {
+	gen := atomic.LoadUint64(&resizes)
+	if gen != p.widthGen {
+		p.width, p.widthGen = terminalWidth(p.out), gen
+	}
+	rows := 0
+	for _, w := range p.drawn {
+		if p.width > 0 && w > p.width {
+			rows += (w + p.width - 1) / p.width
+		} else {
+			rows++
+		}
+	}
+	if rows == 0 {
+		rows = 1
+	}
+	return rows
+}
+
+// clearLine erases the spinner, including the continuation lines of a
+// multi-line message and the rows its lines wrapped onto after the terminal
+// was resized, and leaves the cursor at the start of its first line.
+// The caller must hold p.mu.
+func (p *Pin) clearLine() {
+	if p.inBar {
+		if p.bar.render(p, "") {
+			return
+		}
+		p.inBar = false
+	}
+	rows := p.refreshWidth()
+	_, _ = fmt.Fprint(p.out, "\r\033[K"+strings.Repeat("\033[1A\033[K", rows-1))
+	p.drawn = p.drawn[:0]
+}
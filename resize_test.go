@@ -0,0 +1,49 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package pin_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestResizeRelayoutsSpinner(t *testing.T) {
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+	_ = os.Setenv("COLUMNS", "40")
+	defer os.Unsetenv("COLUMNS")
+
+	var buf syncBuffer
+	p := pin.New("Downloading packages",
+		pin.WithWriter(&buf),
+		pin.WithSpinnerFrames([]rune{'*'}),
+		pin.WithInterval(5*time.Millisecond),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(30 * time.Millisecond)
+	if got := lastFrame(buf.String()); got != "* Downloading packages" {
+		t.Fatalf("Unexpected frame before resize: %q", got)
+	}
+
+	_ = os.Setenv("COLUMNS", "20")
+	sendSignal(t, syscall.SIGWINCH)
+	time.Sleep(50 * time.Millisecond)
+	p.Stop()
+
+	output := buf.String()
+	if got := lastFrame(output); got != "* Downloading pack…" {
+		t.Errorf("Expected the frame to fit the new width, got %q", got)
+	}
+	// The 22-column line wraps onto 2 rows of 20 columns; both are erased.
+	if !strings.Contains(output, "\r\033[K\033[1A\033[K*") {
+		t.Errorf("Expected the wrapped line to be erased, got %q", output)
+	}
+}
@@ -0,0 +1,171 @@
+package pin
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// WithRawMessages disables sanitizing of messages, prefixes and separators,
+// writing them to the terminal exactly as given. Use it only for trusted text
+// that contains escape sequences on purpose.
+func WithRawMessages() Option {
+	return func(p *Pin) {
+		p.raw = true
+	}
+}
+
+// Sanitize makes untrusted text safe to display in a spinner. It keeps
+// printable characters, newlines, SGR color sequences such as "\033[31m" and
+// hyperlinks created by Link, replaces tabs with spaces and invalid UTF-8
+// with U+FFFD, and removes all other control characters and escape
+// sequences, which could move the cursor, rewrite the terminal title or hide
+// output.
+//
+// Spinners sanitize their messages, prefixes and separators unless
+// WithRawMessages is set.
+func Sanitize(s string) string {
+	if isClean(s) {
+		return s
+	}
+
+	var b strings.Builder
+	link := false
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\033':
+			n := escapeSeqLen(s[i:])
+			seq := s[i : i+n]
+			if isSGR(seq) {
+				b.WriteString(seq)
+			} else if url, ok := parseLink(seq); ok && (url != "" || link) {
+				b.WriteString(seq)
+				link = url != ""
+			}
+			i += n
+			continue
+		case c == '\n':
+			b.WriteByte(c)
+		case c == '\t':
+			b.WriteByte(' ')
+		case c < 0x20 || c == 0x7f:
+			// Drop other C0 control characters, including \r.
+		case c < utf8.RuneSelf:
+			b.WriteByte(c)
+		default:
+			r, size := utf8.DecodeRuneInString(s[i:])
+			switch {
+			case r == utf8.RuneError && size == 1:
+				b.WriteRune(utf8.RuneError)
+			case r >= 0x80 && r < 0xa0:
+				// Drop C1 control characters such as U+009B (CSI).
+			default:
+				b.WriteString(s[i : i+size])
+			}
+			i += size
+			continue
+		}
+		i++
+	}
+	return b.String()
+}
+
+// isClean reports whether s contains only printable ASCII characters and
+// newlines, so that Sanitize can return it unchanged.
+func isClean(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < 0x20 && c != '\n') || c >= 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeSeqLen returns the length of the escape sequence at the start of s,
+// which starts with ESC. Unterminated sequences extend to the end of s.
+func escapeSeqLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[': // CSI: parameters and intermediates, then a final byte
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+			if s[i] < 0x20 || s[i] > 0x3f {
+				// Not a valid CSI byte: drop the introducer only.
+				return 2
+			}
+		}
+		return len(s)
+	case ']', 'P', 'X', '^', '_': // OSC, DCS, SOS, PM, APC: strings ended by BEL or ST
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\033' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	}
+	if s[1] < 0x20 || s[1] >= utf8.RuneSelf {
+		// A lone ESC: keep the following character for the main loop.
+		return 1
+	}
+	return 2
+}
+
+// isSGR reports whether seq is a Select Graphic Rendition sequence, which
+// only changes colors and text attributes.
+func isSGR(seq string) bool {
+	if len(seq) < 3 || seq[1] != '[' || seq[len(seq)-1] != 'm' {
+		return false
+	}
+	for i := 2; i < len(seq)-1; i++ {
+		if c := seq[i]; (c < '0' || c > '9') && c != ';' && c != ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitize sanitizes s unless the spinner writes raw messages.
+// The caller must hold p.mu.
+func (p *Pin) sanitize(s string) string {
+	if p.raw {
+		return s
+	}
+	return Sanitize(s)
+}
+
+// clean returns s as plain text: sanitized, with markup and colors removed
+// and hyperlinks spelled out. The caller must hold p.mu.
+func (p *Pin) clean(s string) string {
+	s = p.sanitize(s)
+	if p.markup {
+		s = renderMarkup(s, ColorDefault, false)
+	}
+	return stripSGR(plainLinks(s))
+}
+
+// stripSGR removes the SGR sequences from s.
+func stripSGR(s string) string {
+	if !strings.Contains(s, "\033[") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] == '\033' {
+			n := escapeSeqLen(s[i:])
+			if isSGR(s[i : i+n]) {
+				i += n
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
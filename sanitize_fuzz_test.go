@@ -0,0 +1,89 @@
+//go:build go1.18
+// +build go1.18
+
+package pin_test
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yarlson/pin"
+)
+
+var sgrOnly = regexp.MustCompile("\x1b\\[[0-9;:]*m")
+
+// drawSeqs matches the sequences written by an interactive spinner with the
+// default options.
+var drawSeqs = regexp.MustCompile("\x1b\\[[0-9;:]*m|\r?\x1b\\[K|\x1b\\[1A")
+
+func FuzzSanitize(f *testing.F) {
+	for _, s := range []string{
+		"plain", "a\rb", "\033[31mred\033[0m", "\033]0;title\a", "\033[2J\033[H",
+		"\033P1$r\033\\", "\u009b31m", "\xff\xfe", "\033", "\033[", "\033]8;;x",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		out := pin.Sanitize(s)
+		if !utf8.ValidString(out) {
+			t.Fatalf("Sanitize(%q) = %q is not valid UTF-8", s, out)
+		}
+		for _, r := range sgrOnly.ReplaceAllString(out, "") {
+			if (r < 0x20 && r != '\n') || (r >= 0x7f && r < 0xa0) {
+				t.Fatalf("Sanitize(%q) = %q contains control character %U", s, out, r)
+			}
+		}
+		if pin.Sanitize(out) != out {
+			t.Fatalf("Sanitize is not idempotent for %q", s)
+		}
+
+		// A message without newlines renders as a single line.
+		msg := strings.Replace(s, "\n", "", -1)
+		var buf bytes.Buffer
+		p := pin.New("Working", pin.WithWriter(&buf))
+		cancel := p.Start(context.Background())
+		p.Stop(msg)
+		cancel()
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		if got := lines[len(lines)-1]; strings.ContainsAny(got, "\r\n") {
+			t.Fatalf("Message %q rendered as %q", msg, got)
+		}
+		if len(lines) != 2 {
+			t.Fatalf("Message %q rendered on %d lines: %q", msg, len(lines)-1, buf.String())
+		}
+	})
+}
+
+func FuzzInteractiveOutput(f *testing.F) {
+	for _, s := range []string{
+		"plain", "a\rb", "\033[31mred\033[0m", "\033]0;title\a", "\033[2J\033[H",
+		"multi\nline", "\u009b31m", "\xff\xfe", "\033", "\033]8;;x\033\\link",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		// Force interactive mode.
+		pin.ForceInteractive = true
+		defer func() { pin.ForceInteractive = false }()
+
+		var buf syncBuffer
+		p := pin.New(s, pin.WithWriter(&buf), pin.WithPrefix(s), pin.WithInterval(time.Millisecond))
+		cancel := p.Start(context.Background())
+		p.UpdateMessage(s)
+		time.Sleep(3 * time.Millisecond)
+		p.Fail(s)
+		cancel()
+
+		out := buf.String()
+		for _, r := range drawSeqs.ReplaceAllString(out, "") {
+			if (r < 0x20 && r != '\n') || (r >= 0x7f && r < 0xa0) {
+				t.Fatalf("Message %q rendered with control character %U: %q", s, r, out)
+			}
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package pin_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"multi\nline", "multi\nline"},
+		{"a\tb", "a b"},
+		{"done\rHIDDEN", "doneHIDDEN"},
+		{"\033[31mred\033[0m", "\033[31mred\033[0m"},
+		{"\033[38;2;255;0;0mrgb\033[m", "\033[38;2;255;0;0mrgb\033[m"},
+		{"up\033[1A\033[2Kgone", "upgone"},
+		{"\033]0;pwned\atitle", "title"},
+		{"\033]8;;http://evil\033\\link", "link"},
+		{"\033]52;c;Y2xpcA==", ""},
+		{"\033Pdcs\033\\x", "x"},
+		{"\033cterm", "term"},
+		{"bell\a", "bell"},
+		{"c1\u009b2Jx", "c12Jx"},
+		{"bad\xffutf8", "bad�utf8"},
+		{"ファイル.txt", "ファイル.txt"},
+		{"esc at end\033", "esc at end"},
+	}
+	for _, tt := range tests {
+		if got := pin.Sanitize(tt.in); got != tt.want {
+			t.Errorf("Sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMessagesAreSanitized(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Uploading", pin.WithWriter(&buf), pin.WithPrefix("\033]0;x\a"), pin.WithInterval(5*time.Millisecond))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.UpdateMessage("evil.txt\r\033[2K")
+	time.Sleep(30 * time.Millisecond)
+	p.Fail("server said: \033]2;owned\a\rOK")
+
+	output := buf.String()
+	for _, bad := range []string{"\033]", "\033[2K", "evil.txt\r"} {
+		if strings.Contains(output, bad) {
+			t.Errorf("Expected %q to be removed, got %q", bad, output)
+		}
+	}
+	if !strings.Contains(output, "server said: OK") {
+		t.Errorf("Expected the sanitized failure message, got %q", output)
+	}
+	if p.Message() != "evil.txt\r\033[2K" {
+		t.Errorf("Expected Message to return the text as given, got %q", p.Message())
+	}
+}
+
+func TestSeparatorIsSanitized(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Uploading",
+		pin.WithWriter(&buf),
+		pin.WithPrefix("upload"),
+		pin.WithSeparator("\033]0;pwned\a>"),
+		pin.WithTerminalTitle(),
+		pin.WithInterval(5*time.Millisecond),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(30 * time.Millisecond)
+	p.Stop("Uploaded")
+
+	output := buf.String()
+	if strings.Contains(output, "pwned") {
+		t.Errorf("Expected the separator to be sanitized, got %q", output)
+	}
+	if !strings.Contains(output, "\033]0;upload > Uploading\a") {
+		t.Errorf("Expected the sanitized separator in the title, got %q", output)
+	}
+}
+
+func TestNonInteractiveMessagesAreSanitized(t *testing.T) {
+	var buf bytes.Buffer
+	p := pin.New("a\rb", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("c\033[1Ad")
+
+	if got := buf.String(); got != "ab\ncd\n" {
+		t.Errorf("Expected sanitized lines, got %q", got)
+	}
+}
+
+func TestPlainTextOmitsColors(t *testing.T) {
+	var buf, xml bytes.Buffer
+	report := pin.NewJUnitReport("")
+	p := pin.New("\033[31mred\033[0m step",
+		pin.WithWriter(&buf),
+		pin.WithCIProvider(pin.CIGitHubActions),
+		pin.WithJUnitReport(report),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Fail("\033[31mboom\033[0m")
+
+	if _, err := report.WriteTo(&xml); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	for _, out := range []string{buf.String(), xml.String()} {
+		if strings.Contains(out, "\033") {
+			t.Errorf("Expected plain text without escape sequences, got %q", out)
+		}
+	}
+	if !strings.Contains(buf.String(), "::error::boom\n") {
+		t.Errorf("Expected a plain error annotation, got %q", buf.String())
+	}
+	if !strings.Contains(xml.String(), `name="red step"`) {
+		t.Errorf("Expected a plain test case name, got %q", xml.String())
+	}
+}
+
+func TestWithRawMessages(t *testing.T) {
+	var buf bytes.Buffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithRawMessages())
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("\033]0;title\adone")
+
+	if !strings.Contains(buf.String(), "\033]0;title\adone") {
+		t.Errorf("Expected the message as given, got %q", buf.String())
+	}
+}
@@ -0,0 +1,106 @@
+package pin
+
+import (
+	"io"
+	"time"
+)
+
+// Configure applies options to the spinner. It is safe to call while the
+// spinner is running. Display settings such as colors, symbols, frames, the
+// prefix and the message layout take effect on the next frame. Options that
+// set up a run take effect from the next Start: WithTimeout,
+// WithSignalHandling, WithHiddenCursor, WithTerminalTitle, WithStatusBar,
+// WithCIProvider and WithJUnitReport. Invalid settings are replaced with
+// their defaults, as in New.
+//
+// Example usage:
+//
+//	p.Configure(pin.WithSpinnerColor(pin.ColorYellow), pin.WithPrefix("retry"))
+func (p *Pin) Configure(opts ...Option) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, opt := range opts {
+		opt(p)
+	}
+	_ = p.checkConfig(true)
+}
+
+// SetSpinnerColor sets the color of the spinning animation.
+func (p *Pin) SetSpinnerColor(color Color) {
+	p.Configure(WithSpinnerColor(color))
+}
+
+// SetTextColor sets the color of the message text.
+func (p *Pin) SetTextColor(color Color) {
+	p.Configure(WithTextColor(color))
+}
+
+// SetDoneSymbol sets the symbol displayed when the spinner completes.
+func (p *Pin) SetDoneSymbol(symbol rune) {
+	p.Configure(WithDoneSymbol(symbol))
+}
+
+// SetDoneSymbolColor sets the color of the completion symbol.
+func (p *Pin) SetDoneSymbolColor(color Color) {
+	p.Configure(WithDoneSymbolColor(color))
+}
+
+// SetFailSymbol sets the symbol displayed when the spinner fails.
+func (p *Pin) SetFailSymbol(symbol rune) {
+	p.Configure(WithFailSymbol(symbol))
+}
+
+// SetFailSymbolColor sets the color of the failure symbol.
+func (p *Pin) SetFailSymbolColor(color Color) {
+	p.Configure(WithFailSymbolColor(color))
+}
+
+// SetFailColor sets the color of the failure message text.
+func (p *Pin) SetFailColor(color Color) {
+	p.Configure(WithFailColor(color))
+}
+
+// SetPrefix sets the text displayed before the spinner and message.
+func (p *Pin) SetPrefix(prefix string) {
+	p.Configure(WithPrefix(prefix))
+}
+
+// SetPrefixColor sets the color of the prefix text.
+func (p *Pin) SetPrefixColor(color Color) {
+	p.Configure(WithPrefixColor(color))
+}
+
+// SetSeparator sets the separator text between prefix and message.
+func (p *Pin) SetSeparator(separator string) {
+	p.Configure(WithSeparator(separator))
+}
+
+// SetSeparatorColor sets the color of the separator.
+func (p *Pin) SetSeparatorColor(color Color) {
+	p.Configure(WithSeparatorColor(color))
+}
+
+// SetPosition sets whether the spinner appears before or after the message.
+func (p *Pin) SetPosition(pos Position) {
+	p.Configure(WithPosition(pos))
+}
+
+// SetSpinnerFrames sets the frames for the spinner.
+func (p *Pin) SetSpinnerFrames(frames []rune) {
+	p.Configure(WithSpinnerFrames(frames))
+}
+
+// SetInterval sets the delay between spinner frames.
+func (p *Pin) SetInterval(d time.Duration) {
+	p.Configure(WithInterval(d))
+}
+
+// SetWriter sets the io.Writer for spinner output.
+func (p *Pin) SetWriter(w io.Writer) {
+	p.Configure(WithWriter(w))
+}
+
+// SetTheme applies all non-zero settings of the theme.
+func (p *Pin) SetTheme(t Theme) {
+	p.Configure(WithTheme(t))
+}
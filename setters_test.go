@@ -0,0 +1,113 @@
+package pin_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSettersTakeEffectWhileRunning(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(150 * time.Millisecond)
+
+	p.SetSpinnerColor(pin.ColorYellow)
+	p.SetPrefix("retry")
+	p.SetSpinnerFrames([]rune{'x'})
+	time.Sleep(250 * time.Millisecond)
+	p.Stop("Done")
+
+	output := buf.String()
+	if !strings.Contains(output, pin.ColorYellow.String()+"x") {
+		t.Errorf("Expected new spinner color and frame in output, got %q", output)
+	}
+	if !strings.Contains(output, "retry") {
+		t.Errorf("Expected new prefix in output, got %q", output)
+	}
+}
+
+func TestSetIntervalWhileRunning(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithInterval(20*time.Millisecond))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(50 * time.Millisecond)
+	p.SetInterval(5 * time.Millisecond)
+	p.SetSpinnerFrames([]rune{'z'})
+	time.Sleep(100 * time.Millisecond)
+	p.Stop()
+
+	if n := strings.Count(buf.String(), "z"); n < 5 {
+		t.Errorf("Expected the shorter interval to produce more frames, got %d", n)
+	}
+}
+
+func TestSettersIgnoreInvalidValues(t *testing.T) {
+	p := pin.New("Working")
+	p.SetSpinnerFrames(nil)
+	p.SetInterval(0)
+	p.SetWriter(nil)
+	if err := p.Validate(); err != nil {
+		t.Errorf("Expected invalid values to fall back to defaults, got %v", err)
+	}
+}
+
+func TestConcurrentSetters(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithInterval(time.Millisecond))
+	cancel := p.Start(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p.SetTextColor(pin.ColorCyan)
+			p.SetPosition(pin.Position(i % 2))
+			p.SetSeparator(":")
+			p.SetTheme(pin.ThemeASCII)
+			p.UpdateMessage("step")
+			_ = p.Message()
+		}(i)
+	}
+	wg.Wait()
+	p.Stop("Done")
+}
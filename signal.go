@@ -0,0 +1,261 @@
+package pin
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// WithSignalHandling makes the spinner handle the given signals while it is
+// running, e.g. WithSignalHandling(os.Interrupt, syscall.SIGTERM). When one
+// arrives, the spinner finishes with the interrupt symbol and message,
+// restores the terminal and cancels its context. The signal does not
+// terminate the program: register WithOnInterrupt to decide whether to exit.
+//
+// If no signals are given, os.Interrupt and SIGTERM are handled.
+func WithSignalHandling(sigs ...os.Signal) Option {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	return func(p *Pin) {
+		p.signals = sigs
+	}
+}
+
+// WithInterruptSymbol sets the symbol displayed when a signal interrupts the spinner.
+func WithInterruptSymbol(symbol rune) Option {
+	return func(p *Pin) {
+		p.interruptSymbol = symbol
+	}
+}
+
+// WithInterruptSymbolColor sets the color of the interrupt symbol.
+func WithInterruptSymbolColor(color Color) Option {
+	return func(p *Pin) {
+		p.interruptSymbolColor = color
+	}
+}
+
+// WithInterruptMessage sets the final message displayed when a signal
+// interrupts the spinner. If not set, defaults to "Interrupted"; if set to
+// the empty string, the current spinner message is kept.
+func WithInterruptMessage(message string) Option {
+	return func(p *Pin) {
+		p.interruptMessage = message
+	}
+}
+
+// WithOnInterrupt registers a function called with the signal after it has
+// interrupted the spinner and the terminal has been restored.
+//
+// Example usage:
+//
+//	p := pin.New("Deploying",
+//	    pin.WithSignalHandling(os.Interrupt),
+//	    pin.WithOnInterrupt(func(os.Signal) { os.Exit(130) }),
+//	)
+func WithOnInterrupt(fn func(os.Signal)) Option {
+	return func(p *Pin) {
+		p.onInterrupt = fn
+	}
+}
+
+// WithAppSignalHandler tells pin that the program handles SIGINT and SIGTERM
+// itself, e.g. with signal.Notify. A spinner that hid the cursor or uses a
+// status bar is still finished on those signals, restoring the terminal, but
+// the signal is not raised again, so the program receives it only once.
+//
+// Example usage:
+//
+//	signal.Notify(ch, os.Interrupt)
+//	p := pin.New("Working", pin.WithHiddenCursor(), pin.WithAppSignalHandler())
+func WithAppSignalHandler() Option {
+	return func(p *Pin) {
+		p.appSignals = true
+	}
+}
+
+// interrupt finishes the spinner because of sig.
+func (p *Pin) interrupt(sig os.Signal) {
+	p.mu.RLock()
+	msg, onInterrupt := p.interruptMessage, p.onInterrupt
+	if msg == "" {
+		msg = p.message
+	}
+	p.mu.RUnlock()
+
+	if !p.finish(resultInterrupted, msg) {
+		return
+	}
+	if onInterrupt != nil {
+		onInterrupt(sig)
+	}
+}
+
+// restoreSignals are the signals on which the terminal is restored: the
+// cursor is shown again and a status bar's scroll region is reset.
+var restoreSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// signals tracks the runs that change the terminal or handle signals.
+var signals signalRegistry
+
+// signalEntry describes a run registered for signal handling.
+type signalEntry struct {
+	pin     *Pin
+	restore bool
+	handled []os.Signal
+}
+
+// handles reports whether the run handles sig itself.
+func (e *signalEntry) handles(sig os.Signal) bool {
+	for _, s := range e.handled {
+		if s == sig {
+			return true
+		}
+	}
+	return false
+}
+
+// signalRegistry listens for the signals needed by the registered runs.
+// Signals handled by a run interrupt it; any other signal finishes the runs
+// that changed the terminal and is then raised again so that its default
+// behavior applies.
+type signalRegistry struct {
+	mu       sync.Mutex
+	entries  map[chan struct{}]*signalEntry
+	ch       chan os.Signal
+	notified []os.Signal
+}
+
+// add registers the run identified by done.
+func (r *signalRegistry) add(done chan struct{}, p *Pin, restore bool, handled []os.Signal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.entries == nil {
+		r.entries = make(map[chan struct{}]*signalEntry)
+	}
+	r.entries[done] = &signalEntry{pin: p, restore: restore, handled: handled}
+	r.update()
+}
+
+// remove unregisters the run identified by done.
+func (r *signalRegistry) remove(done chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[done]; !ok {
+		return
+	}
+	delete(r.entries, done)
+	r.update()
+}
+
+// update subscribes to exactly the signals needed by the registered runs.
+// The caller must hold r.mu.
+func (r *signalRegistry) update() {
+	var sigs []os.Signal
+	for _, e := range r.entries {
+		if e.restore {
+			sigs = appendSignals(sigs, restoreSignals...)
+		}
+		sigs = appendSignals(sigs, e.handled...)
+	}
+	if sameSignals(sigs, r.notified) {
+		return
+	}
+
+	if r.ch == nil {
+		r.ch = make(chan os.Signal, 1)
+		go r.listen(r.ch)
+	}
+	signal.Stop(r.ch)
+	if len(sigs) > 0 {
+		signal.Notify(r.ch, sigs...)
+	}
+	r.notified = sigs
+}
+
+// listen dispatches signals received on ch.
+func (r *signalRegistry) listen(ch chan os.Signal) {
+	for sig := range ch {
+		r.dispatch(sig)
+	}
+}
+
+// dispatch interrupts the runs that handle sig. If there are none, it
+// finishes the runs that changed the terminal, which restores it, and raises
+// sig again unless one of them was told that the program handles it.
+func (r *signalRegistry) dispatch(sig os.Signal) {
+	r.mu.Lock()
+	var handlers, restores []*Pin
+	for _, e := range r.entries {
+		switch {
+		case e.handles(sig):
+			handlers = append(handlers, e.pin)
+		case e.restore:
+			restores = append(restores, e.pin)
+		}
+	}
+	r.mu.Unlock()
+
+	if len(handlers) > 0 {
+		for _, p := range handlers {
+			p.interrupt(sig)
+		}
+		return
+	}
+
+	reraise := true
+	for _, p := range restores {
+		p.mu.RLock()
+		if p.appSignals {
+			reraise = false
+		}
+		p.mu.RUnlock()
+		p.finish(resultCanceled)
+	}
+	if !reraise {
+		return
+	}
+	r.mu.Lock()
+	signal.Stop(r.ch)
+	r.notified = nil
+	r.mu.Unlock()
+	raise(sig)
+}
+
+// raise sends sig to the current process, falling back to exiting if the
+// platform cannot deliver it.
+func raise(sig os.Signal) {
+	proc, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = proc.Signal(sig)
+	}
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// appendSignals appends the signals in add that are not already in sigs.
+func appendSignals(sigs []os.Signal, add ...os.Signal) []os.Signal {
+	for _, sig := range add {
+		found := false
+		for _, s := range sigs {
+			if s == sig {
+				found = true
+				break
+			}
+		}
+		if !found {
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs
+}
+
+// sameSignals reports whether a and b contain the same signals.
+func sameSignals(a, b []os.Signal) bool {
+	return len(a) == len(b) && len(appendSignals(a, b...)) == len(a)
+}
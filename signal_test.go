@@ -0,0 +1,159 @@
+package pin_test
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+// sendSignal delivers sig to the test process.
+func sendSignal(t *testing.T, sig os.Signal) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("signals are not supported on Windows")
+	}
+	proc, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := proc.Signal(sig); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSignalInterruptsSpinner(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	interrupted := make(chan os.Signal, 1)
+	p := pin.New("Deploying",
+		pin.WithWriter(&buf),
+		pin.WithHiddenCursor(),
+		pin.WithSignalHandling(os.Interrupt),
+		pin.WithOnInterrupt(func(sig os.Signal) { interrupted <- sig }),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	sendSignal(t, os.Interrupt)
+
+	select {
+	case sig := <-interrupted:
+		if sig != os.Interrupt {
+			t.Errorf("Expected os.Interrupt, got %v", sig)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the interrupt callback")
+	}
+
+	if p.IsRunning() {
+		t.Error("Expected the spinner to be finished after the signal")
+	}
+	output := buf.String()
+	if !strings.Contains(output, "⚠") || !strings.Contains(output, "Interrupted") {
+		t.Errorf("Expected interrupt symbol and message in output, got %q", output)
+	}
+	if !strings.HasSuffix(output, showCursor) {
+		t.Errorf("Expected the cursor to be restored, got %q", output)
+	}
+}
+
+func TestSignalInterruptCustomSymbolAndMessage(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	interrupted := make(chan os.Signal, 1)
+	p := pin.New("Deploying",
+		pin.WithWriter(&buf),
+		pin.WithSignalHandling(),
+		pin.WithInterruptSymbol('^'),
+		pin.WithInterruptSymbolColor(pin.ColorMagenta),
+		pin.WithInterruptMessage("Canceled by user"),
+		pin.WithOnInterrupt(func(sig os.Signal) { interrupted <- sig }),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+
+	sendSignal(t, os.Interrupt)
+
+	select {
+	case <-interrupted:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the interrupt callback")
+	}
+	output := buf.String()
+	if !strings.Contains(output, pin.ColorMagenta.String()+"^") || !strings.Contains(output, "Canceled by user") {
+		t.Errorf("Expected custom interrupt symbol and message in output, got %q", output)
+	}
+}
+
+func TestStoppedSpinnerIgnoresSignal(t *testing.T) {
+	interrupted := make(chan os.Signal, 1)
+	var buf syncBuffer
+	p := pin.New("Deploying",
+		pin.WithWriter(&buf),
+		pin.WithSignalHandling(os.Interrupt),
+		pin.WithOnInterrupt(func(sig os.Signal) { interrupted <- sig }),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+
+	// Keep another handler registered so the signal does not terminate the test.
+	other := pin.New("Other", pin.WithWriter(&buf), pin.WithSignalHandling(os.Interrupt))
+	cancelOther := other.Start(context.Background())
+	defer cancelOther()
+
+	p.Stop("Done")
+	sendSignal(t, os.Interrupt)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for other.IsRunning() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case <-interrupted:
+		t.Error("Expected a stopped spinner not to be interrupted")
+	default:
+	}
+	if other.IsRunning() {
+		t.Error("Expected the running spinner to be interrupted")
+	}
+}
+
+func TestAppSignalHandlerReceivesSignalOnce(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	app := make(chan os.Signal, 4)
+	signal.Notify(app, os.Interrupt)
+	defer signal.Stop(app)
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithHiddenCursor(), pin.WithAppSignalHandler())
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	sendSignal(t, os.Interrupt)
+	waitStopped(t, p)
+	time.Sleep(100 * time.Millisecond)
+
+	if n := len(app); n != 1 {
+		t.Errorf("Expected the program to receive the signal once, got %d", n)
+	}
+	if output := buf.String(); !strings.HasSuffix(output, showCursor) {
+		t.Errorf("Expected the spinner to finish and show the cursor, got %q", output)
+	}
+}
@@ -0,0 +1,147 @@
+package pin
+
+import (
+	"fmt"
+	"time"
+)
+
+// WarningKind identifies why a spinner switched to its warning look.
+type WarningKind int
+
+const (
+	WarningStalled WarningKind = iota // No activity for longer than the stall threshold
+	WarningSlow                       // Running for longer than the slow threshold
+)
+
+// String returns the name of the warning kind.
+func (k WarningKind) String() string {
+	switch k {
+	case WarningStalled:
+		return "stalled"
+	case WarningSlow:
+		return "slow"
+	default:
+		return "unknown"
+	}
+}
+
+// Warning describes a threshold crossed by a running spinner.
+type Warning struct {
+	Kind    WarningKind
+	Message string        // The spinner message at the time of the warning
+	Idle    time.Duration // Time since the last UpdateMessage or Touch
+	Elapsed time.Duration // Run time of the spinner
+}
+
+// WithStallThreshold switches the spinner to its warning look if neither
+// UpdateMessage nor Touch has been called for d. It switches back on the
+// next activity.
+func WithStallThreshold(d time.Duration) Option {
+	return func(p *Pin) {
+		p.stallAfter = d
+	}
+}
+
+// WithSlowThreshold switches the spinner to its warning look once it has
+// been running for longer than d.
+func WithSlowThreshold(d time.Duration) Option {
+	return func(p *Pin) {
+		p.slowAfter = d
+	}
+}
+
+// WithWarningColor sets the spinner color used once a threshold is crossed.
+// If not set, defaults to ColorYellow.
+func WithWarningColor(color Color) Option {
+	return func(p *Pin) {
+		p.warningColor = color
+	}
+}
+
+// WithWarningSymbol replaces the animation frames with symbol once a
+// threshold is crossed. If not set, the frames keep animating.
+func WithWarningSymbol(symbol rune) Option {
+	return func(p *Pin) {
+		p.warningSymbol = symbol
+	}
+}
+
+// WithStallNotice appends "(no activity for 45s)" to the message while the
+// spinner is stalled.
+func WithStallNotice() Option {
+	return func(p *Pin) {
+		p.stallNotice = true
+	}
+}
+
+// WithOnWarning registers a function called once each time a threshold is
+// crossed, e.g. to log the stall or to abort the step. It runs on its own
+// goroutine, so it may call Stop or Fail.
+//
+// Example usage:
+//
+//	p := pin.New("Fetching",
+//	    pin.WithStallThreshold(45*time.Second),
+//	    pin.WithOnWarning(func(w pin.Warning) {
+//	        log.Printf("%s: no activity for %v", w.Message, w.Idle)
+//	    }),
+//	)
+func WithOnWarning(fn func(Warning)) Option {
+	return func(p *Pin) {
+		p.onWarning = fn
+	}
+}
+
+// Touch records activity without changing the message, resetting the stall
+// threshold.
+func (p *Pin) Touch() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.active() {
+		p.touch()
+	}
+}
+
+// touch records activity. The caller must hold p.mu.
+func (p *Pin) touch() {
+	p.lastActivity = time.Now()
+	p.stalled = false
+}
+
+// checkWarnings marks the thresholds crossed by a running spinner at now and
+// returns the warnings that were newly raised. The caller must hold p.mu.
+func (p *Pin) checkWarnings(now time.Time) []Warning {
+	if p.state != StateRunning {
+		return nil
+	}
+
+	var warnings []Warning
+	idle := now.Sub(p.lastActivity)
+	if p.stallAfter > 0 && !p.stalled && idle >= p.stallAfter {
+		p.stalled = true
+		warnings = append(warnings, Warning{Kind: WarningStalled, Message: p.message, Idle: idle, Elapsed: p.elapsed()})
+	}
+	if p.slowAfter > 0 && !p.slow && p.elapsed() >= p.slowAfter {
+		p.slow = true
+		warnings = append(warnings, Warning{Kind: WarningSlow, Message: p.message, Idle: idle, Elapsed: p.elapsed()})
+	}
+	return warnings
+}
+
+// warningFrame returns the frame to draw once a threshold is crossed.
+// The caller must hold p.mu.
+func (p *Pin) warningFrame(frame rune) rune {
+	if p.warningSymbol != 0 {
+		return p.warningSymbol
+	}
+	return frame
+}
+
+// stallSuffix returns the notice appended to the message while stalled.
+// The caller must hold p.mu.
+func (p *Pin) stallSuffix() string {
+	if !p.stalled || !p.stallNotice {
+		return ""
+	}
+	return fmt.Sprintf(" (no activity for %v)", roundDuration(time.Since(p.lastActivity)))
+}
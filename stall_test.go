@@ -0,0 +1,123 @@
+package pin_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestStallSwitchesToWarningLook(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	warnings := make(chan pin.Warning, 2)
+	p := pin.New("Fetching",
+		pin.WithWriter(&buf),
+		pin.WithInterval(10*time.Millisecond),
+		pin.WithStallThreshold(50*time.Millisecond),
+		pin.WithWarningColor(pin.ColorMagenta),
+		pin.WithWarningSymbol('!'),
+		pin.WithStallNotice(),
+		pin.WithOnWarning(func(w pin.Warning) { warnings <- w }),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+
+	select {
+	case w := <-warnings:
+		if w.Kind != pin.WarningStalled || w.Message != "Fetching" || w.Idle < 50*time.Millisecond {
+			t.Errorf("Unexpected warning %+v", w)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the stall warning")
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	output := buf.String()
+	if !strings.Contains(output, pin.ColorMagenta.String()+"!") {
+		t.Errorf("Expected warning color and symbol in output, got %q", output)
+	}
+	if !strings.Contains(output, "Fetching (no activity for ") {
+		t.Errorf("Expected stall notice in output, got %q", output)
+	}
+
+	// Activity switches the spinner back to its normal look.
+	p.UpdateMessage("Still fetching")
+	time.Sleep(30 * time.Millisecond)
+	p.Stop()
+	output = buf.String()
+	last := output[strings.LastIndex(output, "Still fetching")-20:]
+	if strings.Contains(last, "!") {
+		t.Errorf("Expected normal look after activity, got %q", last)
+	}
+}
+
+func TestTouchResetsStall(t *testing.T) {
+	warnings := make(chan pin.Warning, 1)
+	var buf syncBuffer
+	p := pin.New("Fetching",
+		pin.WithWriter(&buf),
+		pin.WithInterval(5*time.Millisecond),
+		pin.WithStallThreshold(60*time.Millisecond),
+		pin.WithOnWarning(func(w pin.Warning) { warnings <- w }),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	for i := 0; i < 6; i++ {
+		time.Sleep(20 * time.Millisecond)
+		p.Touch()
+	}
+	p.Stop()
+
+	select {
+	case w := <-warnings:
+		t.Errorf("Expected no stall warning while touching, got %+v", w)
+	default:
+	}
+}
+
+func TestSlowThresholdWarnsOnce(t *testing.T) {
+	warnings := make(chan pin.Warning, 4)
+	var buf syncBuffer
+	p := pin.New("Compiling",
+		pin.WithWriter(&buf),
+		pin.WithInterval(5*time.Millisecond),
+		pin.WithSlowThreshold(20*time.Millisecond),
+		pin.WithOnWarning(func(w pin.Warning) { warnings <- w }),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(80 * time.Millisecond)
+	p.Stop()
+	time.Sleep(10 * time.Millisecond)
+
+	if n := len(warnings); n != 1 {
+		t.Fatalf("Expected exactly one slow warning, got %d", n)
+	}
+	if w := <-warnings; w.Kind != pin.WarningSlow || w.Elapsed < 20*time.Millisecond {
+		t.Errorf("Unexpected warning %+v", w)
+	}
+}
+
+func TestOnWarningCanFailSpinner(t *testing.T) {
+	var buf syncBuffer
+	var p *pin.Pin
+	p = pin.New("Fetching",
+		pin.WithWriter(&buf),
+		pin.WithInterval(5*time.Millisecond),
+		pin.WithStallThreshold(20*time.Millisecond),
+		pin.WithOnWarning(func(w pin.Warning) { p.Fail("aborted: stalled") }),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	waitStopped(t, p)
+
+	if !strings.Contains(buf.String(), "aborted: stalled") {
+		t.Errorf("Expected the callback to fail the spinner, got %q", buf.String())
+	}
+}
@@ -0,0 +1,67 @@
+package pin
+
+import "time"
+
+// State is a stage in the lifecycle of a spinner.
+//
+// A spinner starts out idle, becomes running on Start and finished on Stop,
+// Fail or cancellation of its context. Pause and Resume move a started
+// spinner between running and paused. A finished spinner can be started again.
+type State int
+
+const (
+	StateIdle     State = iota // Created but never started
+	StateRunning               // Animating
+	StatePaused                // Started, but rendering is suspended
+	StateFinished              // Stopped, failed or canceled
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateIdle:
+		return "idle"
+	case StateRunning:
+		return "running"
+	case StatePaused:
+		return "paused"
+	case StateFinished:
+		return "finished"
+	default:
+		return "unknown"
+	}
+}
+
+// State returns the current lifecycle state of the spinner.
+func (p *Pin) State() State {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.state
+}
+
+// active reports whether the spinner has been started and not yet finished.
+// The caller must hold p.mu.
+func (p *Pin) active() bool {
+	return p.state == StateRunning || p.state == StatePaused
+}
+
+// markFinished moves an active spinner to the finished state. Until settle
+// is called, the run is finishing and Start waits for it.
+// The caller must hold p.mu.
+func (p *Pin) markFinished() {
+	now := time.Now()
+	if p.state == StatePaused {
+		p.pausedFor += now.Sub(p.pausedAt)
+	}
+	p.state = StateFinished
+	p.ended = now
+	p.finishing = make(chan struct{})
+}
+
+// settle ends the finishing of a run once its final message is printed and
+// resets the progress for the next run. The caller must hold p.mu.
+func (p *Pin) settle() {
+	close(p.finishing)
+	p.finishing = nil
+	p.progress = -1
+}
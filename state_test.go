@@ -0,0 +1,198 @@
+package pin_test
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestStateTransitions(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf))
+	if p.State() != pin.StateIdle {
+		t.Fatalf("Expected idle state, got %v", p.State())
+	}
+	cancel := p.Start(context.Background())
+	defer cancel()
+	if p.State() != pin.StateRunning {
+		t.Fatalf("Expected running state, got %v", p.State())
+	}
+	p.Stop("Done")
+	if p.State() != pin.StateFinished {
+		t.Fatalf("Expected finished state, got %v", p.State())
+	}
+}
+
+func TestStartAfterStopRestarts(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithInterval(10*time.Millisecond))
+	for i := 0; i < 3; i++ {
+		cancel := p.Start(context.Background())
+		if !p.IsRunning() {
+			t.Fatalf("Run %d: expected spinner to be running", i)
+		}
+		time.Sleep(30 * time.Millisecond)
+		p.Stop("Done")
+		cancel()
+	}
+	if n := strings.Count(buf.String(), "Done"); n != 3 {
+		t.Errorf("Expected 3 final messages, got %d in %q", n, buf.String())
+	}
+}
+
+func TestStartAfterCancelRestarts(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	cancel()
+	// Start must not be a no-op even if the canceled run has not wound down yet.
+	cancel = p.Start(context.Background())
+	defer cancel()
+	if !p.IsRunning() {
+		t.Fatal("Expected spinner to be running after restart")
+	}
+	p.Stop("Done")
+	if !strings.Contains(buf.String(), "Done") {
+		t.Errorf("Expected final message after restart, got %q", buf.String())
+	}
+}
+
+func TestNonInteractiveStopEndsRun(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("Done")
+	if p.IsRunning() {
+		t.Error("Expected spinner to be stopped")
+	}
+	cancel = p.Start(context.Background())
+	defer cancel()
+	if !p.IsRunning() {
+		t.Error("Expected spinner to restart in non-interactive mode")
+	}
+	p.Stop()
+}
+
+func TestConcurrentStopFailCancelPrintOnce(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithInterval(time.Millisecond))
+	cancel := p.Start(context.Background())
+	time.Sleep(10 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			p.Stop("Result")
+		}()
+		go func() {
+			defer wg.Done()
+			p.Fail("Result")
+		}()
+		go func() {
+			defer wg.Done()
+			cancel()
+		}()
+	}
+	wg.Wait()
+
+	if n := strings.Count(buf.String(), "Result"); n > 1 {
+		t.Errorf("Expected at most one final message, got %d", n)
+	}
+	if p.IsRunning() {
+		t.Error("Expected spinner to be stopped")
+	}
+}
+
+func TestLifecycleStress(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf), pin.WithInterval(time.Millisecond))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				switch (i + j) % 6 {
+				case 0:
+					cancel := p.Start(context.Background())
+					if j%2 == 0 {
+						cancel()
+					}
+				case 1:
+					p.Stop("stopped")
+				case 2:
+					p.Fail("failed")
+				case 3:
+					p.UpdateMessage("update")
+				case 4:
+					_ = p.Message()
+					_ = p.IsRunning()
+					_ = p.State()
+				case 5:
+					p.SetSpinnerColor(pin.ColorCyan)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	p.Stop()
+
+	if p.IsRunning() {
+		t.Error("Expected spinner to be stopped at the end of the stress test")
+	}
+}
+
+func TestStartWaitsForFinishingRun(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	for i := 0; i < 200; i++ {
+		var buf syncBuffer
+		p := pin.New("Working", pin.WithWriter(&buf), pin.WithInterval(time.Millisecond), pin.WithHiddenCursor())
+		p.Start(context.Background())
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			p.Stop("Done")
+		}()
+		go func() {
+			defer wg.Done()
+			// Start again as soon as Stop has finished the run.
+			for p.State() != pin.StateFinished {
+				runtime.Gosched()
+			}
+			p.Start(context.Background())
+		}()
+		wg.Wait()
+		p.Stop()
+
+		output := buf.String()
+		if hidden, shown := strings.Count(output, "\033[?25l"), strings.Count(output, "\033[?25h"); hidden != shown {
+			t.Fatalf("Expected every hidden cursor to be shown again, hid %d and showed %d times in %q", hidden, shown, output)
+		}
+	}
+}
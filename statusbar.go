@@ -0,0 +1,232 @@
+package pin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	escSaveCursor    = "\0337"
+	escRestoreCursor = "\0338"
+	escResetRegion   = "\033[r"
+)
+
+// StatusBar pins spinners to the bottom of the terminal while other output
+// scrolls above them, like the progress display of modern package managers.
+// Each running spinner attached with WithStatusBar gets one of the last
+// lines of the terminal; the lines above form a scroll region (DECSTBM).
+//
+// Output written to the StatusBar goes to the scrolling area. Write program
+// output through it, rather than directly to the terminal, so that it never
+// interleaves with the spinners. Final messages printed by Stop and Fail
+// also go to the scrolling area.
+//
+// The scroll region is adjusted as spinners start and finish, set up again
+// when the terminal is resized and reset when the last spinner finishes, on
+// Close and when the process is terminated by a signal. If the output is not
+// a terminal, or its height is unknown, spinners are drawn as usual.
+//
+// Example usage:
+//
+//	bar := pin.NewStatusBar(os.Stdout)
+//	defer bar.Close()
+//	log.SetOutput(bar)
+//
+//	p := pin.New("Installing", pin.WithStatusBar(bar))
+//	cancel := p.Start(ctx)
+//	defer cancel()
+//	log.Println("fetched lodash@4.17.21") // scrolls above the spinner
+type StatusBar struct {
+	out io.Writer
+
+	mu       sync.Mutex
+	pins     []*Pin          // spinners on the reserved lines, top to bottom
+	lines    map[*Pin]string // last line drawn by each spinner
+	rows     int             // terminal height when the region was set
+	reserved int             // number of reserved lines
+	gen      uint64          // value of resizes when rows was queried
+}
+
+// NewStatusBar creates a status bar on out, which is usually os.Stdout.
+// A nil out writes to os.Stdout.
+func NewStatusBar(out io.Writer) *StatusBar {
+	if out == nil {
+		out = os.Stdout
+	}
+	return &StatusBar{out: out, lines: make(map[*Pin]string)}
+}
+
+// WithStatusBar draws the spinner on a line reserved at the bottom of the
+// terminal by the status bar. It replaces the writer set with WithWriter.
+// A nil status bar is ignored. Applied with Configure to a running spinner,
+// it releases the line on the previous status bar and takes effect from the
+// next Start.
+func WithStatusBar(b *StatusBar) Option {
+	return func(p *Pin) {
+		if b == nil || b == p.bar {
+			return
+		}
+		if p.inBar {
+			p.bar.detach(p)
+			p.inBar = false
+		}
+		p.bar = b
+		p.out = b
+	}
+}
+
+// Write writes data to the scrolling area. It is safe for concurrent use.
+func (b *StatusBar) Write(data []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+	return b.out.Write(data)
+}
+
+// Close resets the scroll region and clears the reserved lines. Spinners
+// still running on the status bar are drawn as usual from then on.
+func (b *StatusBar) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pins = nil
+	b.lines = make(map[*Pin]string)
+	b.layout(b.rows)
+	return nil
+}
+
+// attach reserves a line for p and reports whether it did. It fails if the
+// terminal height is unknown or too small for another line.
+func (b *StatusBar) attach(p *Pin) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	watchResize()
+	_, rows := terminalSize(b.out)
+	if rows <= len(b.pins)+1 {
+		return false
+	}
+	b.pins = append(b.pins, p)
+	b.gen = atomic.LoadUint64(&resizes)
+	b.layout(rows)
+	return true
+}
+
+// detach releases the line reserved for p.
+func (b *StatusBar) detach(p *Pin) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, q := range b.pins {
+		if q == p {
+			b.pins = append(b.pins[:i], b.pins[i+1:]...)
+			delete(b.lines, p)
+			b.refresh()
+			b.layout(b.rows)
+			return
+		}
+	}
+}
+
+// render draws line on the line reserved for p. It returns false if p no
+// longer has a line, e.g. after Close.
+func (b *StatusBar) render(p *Pin, line string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.has(p) {
+		return false
+	}
+	b.lines[p] = line
+	b.refresh()
+	if i := b.index(p); i >= 0 {
+		_, _ = fmt.Fprint(b.out, escSaveCursor+b.moveTo(i)+line+escRestoreCursor)
+	}
+	return true
+}
+
+// has reports whether p is attached. The caller must hold b.mu.
+func (b *StatusBar) has(p *Pin) bool {
+	for _, q := range b.pins {
+		if q == p {
+			return true
+		}
+	}
+	return false
+}
+
+// index returns the reserved line of p, counted from the top of the
+// reserved lines, or -1 if p has none. The caller must hold b.mu.
+func (b *StatusBar) index(p *Pin) int {
+	if b.reserved != len(b.pins) {
+		return -1
+	}
+	for i, q := range b.pins {
+		if q == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveTo returns the sequence moving the cursor to the start of the i-th
+// reserved line and clearing it. The caller must hold b.mu.
+func (b *StatusBar) moveTo(i int) string {
+	return fmt.Sprintf("\033[%d;1H\033[2K", b.rows-b.reserved+1+i)
+}
+
+// refresh sets the region up again if the terminal was resized.
+// The caller must hold b.mu.
+func (b *StatusBar) refresh() {
+	gen := atomic.LoadUint64(&resizes)
+	if gen == b.gen {
+		return
+	}
+	b.gen = gen
+	_, rows := terminalSize(b.out)
+	if rows == b.rows || b.reserved == 0 {
+		b.rows = rows
+		return
+	}
+	// The old reserved lines have moved; leave them to the scrollback.
+	b.reserved = 0
+	b.layout(rows)
+}
+
+// layout clears the reserved lines and reserves one line for each attached
+// spinner in a terminal of the given height, keeping the cursor where it is
+// in the scrolling area. The caller must hold b.mu.
+func (b *StatusBar) layout(rows int) {
+	if b.reserved == 0 && len(b.pins) == 0 {
+		b.rows = rows
+		return
+	}
+
+	var s strings.Builder
+	if b.reserved > 0 {
+		s.WriteString(escSaveCursor)
+		for i := 0; i < b.reserved; i++ {
+			s.WriteString(b.moveTo(i))
+		}
+		s.WriteString(escResetRegion + escRestoreCursor)
+	}
+
+	b.rows, b.reserved = rows, 0
+	n := len(b.pins)
+	if n > 0 && rows > n {
+		// Make room below the cursor, scrolling only as far as needed,
+		// then confine scrolling to the lines above the reserved ones.
+		s.WriteString(strings.Repeat("\n", n))
+		fmt.Fprintf(&s, "%s\033[1;%dr%s\033[%dA", escSaveCursor, rows-n, escRestoreCursor, n)
+		b.reserved = n
+		s.WriteString(escSaveCursor)
+		for i, p := range b.pins {
+			s.WriteString(b.moveTo(i) + b.lines[p])
+		}
+		s.WriteString(escRestoreCursor)
+	}
+	_, _ = fmt.Fprint(b.out, s.String())
+}
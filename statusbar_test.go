@@ -0,0 +1,186 @@
+package pin_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestStatusBarReservesBottomLine(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+	_ = os.Setenv("LINES", "24")
+	defer os.Unsetenv("LINES")
+
+	var buf syncBuffer
+	bar := pin.NewStatusBar(&buf)
+	p := pin.New("Installing", pin.WithStatusBar(bar))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(150 * time.Millisecond)
+
+	output := buf.String()
+	if want := "\n\0337\033[1;23r\0338\033[1A"; !strings.HasPrefix(output, want) {
+		t.Fatalf("Expected the scroll region to be set first, got %q", output)
+	}
+	if !strings.Contains(output, "\0337\033[24;1H\033[2K") || !strings.Contains(output, "Installing\033[0m\0338") {
+		t.Errorf("Expected the spinner to be drawn on the last line, got %q", output)
+	}
+
+	_, _ = bar.Write([]byte("fetched lodash\n"))
+	p.Stop("Installed")
+
+	output = buf.String()
+	if !strings.Contains(output, "fetched lodash\n") {
+		t.Errorf("Expected writes to pass through to the scrolling area, got %q", output)
+	}
+	if !strings.Contains(output, "Installed") {
+		t.Errorf("Expected the final message in the output, got %q", output)
+	}
+	if !strings.HasSuffix(output, "\033[r\0338") {
+		t.Errorf("Expected the scroll region to be reset, got %q", output)
+	}
+}
+
+func TestStatusBarMultipleSpinners(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+	_ = os.Setenv("LINES", "24")
+	defer os.Unsetenv("LINES")
+
+	var buf syncBuffer
+	bar := pin.NewStatusBar(&buf)
+	defer bar.Close()
+
+	first := pin.New("First", pin.WithStatusBar(bar))
+	cancelFirst := first.Start(context.Background())
+	defer cancelFirst()
+	second := pin.New("Second", pin.WithStatusBar(bar))
+	cancelSecond := second.Start(context.Background())
+	defer cancelSecond()
+	time.Sleep(150 * time.Millisecond)
+
+	output := buf.String()
+	if !strings.Contains(output, "\033[1;22r") {
+		t.Errorf("Expected two lines to be reserved, got %q", output)
+	}
+	if !strings.Contains(output, "\033[23;1H\033[2K") || !strings.Contains(output, "\033[24;1H\033[2K") {
+		t.Errorf("Expected the spinners on the last two lines, got %q", output)
+	}
+
+	n := len(buf.String())
+	first.Stop("Done")
+	if output := buf.String()[n:]; !strings.Contains(output, "\033[1;23r") || !strings.Contains(output, "Second") {
+		t.Errorf("Expected one line to stay reserved for the second spinner, got %q", output)
+	}
+}
+
+func TestStatusBarClose(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+	_ = os.Setenv("LINES", "24")
+	defer os.Unsetenv("LINES")
+
+	var buf syncBuffer
+	bar := pin.NewStatusBar(&buf)
+	p := pin.New("Installing", pin.WithStatusBar(bar))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	if err := bar.Close(); err != nil {
+		t.Fatalf("Close returned %v", err)
+	}
+	if output := buf.String(); !strings.HasSuffix(output, "\033[r\0338") {
+		t.Errorf("Expected Close to reset the scroll region, got %q", output)
+	}
+
+	// The spinner is drawn inline from now on.
+	time.Sleep(150 * time.Millisecond)
+	p.Stop("Installed")
+	output := buf.String()
+	i := strings.LastIndex(output, "\033[r")
+	if tail := output[i:]; strings.Contains(tail, "\033[24;1H") || !strings.Contains(tail, "\r\033[K") {
+		t.Errorf("Expected the spinner to be drawn inline after Close, got %q", tail)
+	}
+}
+
+func TestStatusBarUnknownHeight(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Installing", pin.WithStatusBar(pin.NewStatusBar(&buf)))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(150 * time.Millisecond)
+	p.Stop("Installed")
+
+	if output := buf.String(); strings.Contains(output, "\0337") || strings.Contains(output, "\033[r") {
+		t.Errorf("Expected the spinner to be drawn inline, got %q", output)
+	}
+}
+
+func TestStatusBarNonInteractive(t *testing.T) {
+	_ = os.Setenv("LINES", "24")
+	defer os.Unsetenv("LINES")
+
+	var buf syncBuffer
+	bar := pin.NewStatusBar(&buf)
+	p := pin.New("Installing", pin.WithStatusBar(bar))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	_, _ = bar.Write([]byte("fetched lodash\n"))
+	p.Stop("Installed")
+
+	if got, want := buf.String(), "Installing\nfetched lodash\nInstalled\n"; got != want {
+		t.Errorf("Expected output %q, got %q", want, got)
+	}
+}
+
+func TestWithStatusBarNil(t *testing.T) {
+	var buf syncBuffer
+	p, err := pin.NewE("Installing", pin.WithWriter(&buf), pin.WithStatusBar(nil))
+	if err != nil {
+		t.Fatalf("Expected a nil status bar to be ignored, got %v", err)
+	}
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("Installed")
+
+	if got, want := buf.String(), "Installing\nInstalled\n"; got != want {
+		t.Errorf("Expected output %q, got %q", want, got)
+	}
+}
+
+func TestStatusBarSwitchedWhileRunning(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+	_ = os.Setenv("LINES", "24")
+	defer os.Unsetenv("LINES")
+
+	var first, second syncBuffer
+	p := pin.New("Installing", pin.WithStatusBar(pin.NewStatusBar(&first)))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	p.Configure(pin.WithStatusBar(pin.NewStatusBar(&second)))
+	p.Stop("Installed")
+
+	if output := first.String(); !strings.HasSuffix(output, "\033[r\0338") {
+		t.Errorf("Expected the first status bar to reset the scroll region, got %q", output)
+	}
+	if output := second.String(); strings.Contains(output, "\0337") {
+		t.Errorf("Expected no lines reserved on the second status bar, got %q", output)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package pin
+
+import "os"
+
+// fileSize returns zeros: querying the terminal size is not supported on
+// this platform, so terminalSize falls back to $COLUMNS and $LINES.
+func fileSize(f *os.File) (cols, rows int) {
+	return 0, 0
+}
+
+// watchResize does nothing: there is no resize signal on this platform.
+func watchResize() {}
@@ -0,0 +1,45 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package pin
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the struct filled in by the TIOCGWINSZ ioctl.
+type winsize struct {
+	rows, cols, xpixel, ypixel uint16
+}
+
+// fileSize returns the number of columns and rows of the terminal f refers
+// to, or zeros if it is not a terminal.
+func fileSize(f *os.File) (cols, rows int) {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0, 0
+	}
+	return int(ws.cols), int(ws.rows)
+}
+
+var resizeOnce sync.Once
+
+// watchResize starts counting SIGWINCH signals, which the terminal sends
+// when it is resized.
+func watchResize() {
+	resizeOnce.Do(func() {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, syscall.SIGWINCH)
+		go func() {
+			for range ch {
+				atomic.AddUint64(&resizes, 1)
+			}
+		}()
+	})
+}
@@ -0,0 +1,202 @@
+package pin
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// ThemeEnv is the environment variable consulted by WithThemeFromEnv.
+// Its value is the name of a built-in theme, e.g. PIN_THEME=ascii, or the
+// path to a JSON theme file.
+const ThemeEnv = "PIN_THEME"
+
+// Theme bundles the visual settings of a spinner so that a whole set of tools
+// can share one look.
+//
+// Zero-valued fields leave the corresponding setting unchanged, which allows
+// partial themes to be layered on top of each other. Position is a pointer
+// because its zero value, PositionLeft, is a valid choice.
+//
+// Example usage:
+//
+//	p := pin.New("Building", pin.WithTheme(pin.ThemeASCII))
+type Theme struct {
+	Name            string
+	Frames          []rune
+	Interval        time.Duration
+	SpinnerColor    Color
+	TextColor       Color
+	DoneSymbol      rune
+	DoneSymbolColor Color
+	FailSymbol      rune
+	FailSymbolColor Color
+	FailColor       Color
+	PrefixColor     Color
+	Separator       string
+	SeparatorColor  Color
+	Position        *Position
+}
+
+// Built-in themes.
+var (
+	// ThemeDefault matches the look of a spinner created without options.
+	ThemeDefault = Theme{
+		Name:            "default",
+		Frames:          defaultFrames,
+		Interval:        defaultInterval,
+		DoneSymbol:      '✓',
+		DoneSymbolColor: ColorGreen,
+		FailSymbol:      '✖',
+		FailSymbolColor: ColorRed,
+		Separator:       "›",
+		SeparatorColor:  ColorWhite,
+		Position:        positionOf(PositionLeft),
+	}
+
+	// ThemeASCII uses only ASCII characters, for terminals without Unicode fonts.
+	ThemeASCII = Theme{
+		Name:            "ascii",
+		Frames:          []rune{'|', '/', '-', '\\'},
+		Interval:        120 * time.Millisecond,
+		DoneSymbol:      '+',
+		DoneSymbolColor: ColorGreen,
+		FailSymbol:      'x',
+		FailSymbolColor: ColorRed,
+		Separator:       ">",
+		SeparatorColor:  ColorWhite,
+		Position:        positionOf(PositionLeft),
+	}
+
+	// ThemeDots is a calm, colorful theme with a bouncing dot animation.
+	ThemeDots = Theme{
+		Name:            "dots",
+		Frames:          []rune{'⣾', '⣽', '⣻', '⢿', '⡿', '⣟', '⣯', '⣷'},
+		Interval:        80 * time.Millisecond,
+		SpinnerColor:    ColorCyan,
+		DoneSymbol:      '●',
+		DoneSymbolColor: ColorGreen,
+		FailSymbol:      '●',
+		FailSymbolColor: ColorRed,
+		PrefixColor:     ColorMagenta,
+		Separator:       "·",
+		SeparatorColor:  ColorGray,
+		Position:        positionOf(PositionLeft),
+	}
+
+	// ThemeArc draws a rotating arc and places it after the message.
+	ThemeArc = Theme{
+		Name:            "arc",
+		Frames:          []rune{'◜', '◠', '◝', '◞', '◡', '◟'},
+		Interval:        100 * time.Millisecond,
+		SpinnerColor:    ColorBlue,
+		DoneSymbol:      '✔',
+		DoneSymbolColor: ColorGreen,
+		FailSymbol:      '✘',
+		FailSymbolColor: ColorRed,
+		FailColor:       ColorRed,
+		PrefixColor:     ColorBlue,
+		Separator:       "→",
+		SeparatorColor:  ColorGray,
+		Position:        positionOf(PositionRight),
+	}
+)
+
+// positionOf returns a pointer to pos, for use in Theme literals.
+func positionOf(pos Position) *Position {
+	return &pos
+}
+
+// Themes returns the built-in themes.
+func Themes() []Theme {
+	return []Theme{ThemeDefault, ThemeASCII, ThemeDots, ThemeArc}
+}
+
+// ThemeByName returns the built-in theme with the given name.
+// The lookup is case-insensitive.
+func ThemeByName(name string) (Theme, bool) {
+	for _, t := range Themes() {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// WithTheme applies all non-zero settings of the theme.
+// Options passed after WithTheme override the theme's settings.
+func WithTheme(t Theme) Option {
+	return func(p *Pin) {
+		if len(t.Frames) > 0 {
+			p.frames = t.Frames
+		}
+		if t.Interval > 0 {
+			p.interval = t.Interval
+		}
+		if t.SpinnerColor != ColorDefault {
+			p.spinnerColor = t.SpinnerColor
+		}
+		if t.TextColor != ColorDefault {
+			p.textColor = t.TextColor
+		}
+		if t.DoneSymbol != 0 {
+			p.doneSymbol = t.DoneSymbol
+		}
+		if t.DoneSymbolColor != ColorDefault {
+			p.doneSymbolColor = t.DoneSymbolColor
+		}
+		if t.FailSymbol != 0 {
+			p.failSymbol = t.FailSymbol
+		}
+		if t.FailSymbolColor != ColorDefault {
+			p.failSymbolColor = t.FailSymbolColor
+		}
+		if t.FailColor != ColorDefault {
+			p.failColor = t.FailColor
+		}
+		if t.PrefixColor != ColorDefault {
+			p.prefixColor = t.PrefixColor
+		}
+		if t.Separator != "" {
+			p.separator = t.Separator
+		}
+		if t.SeparatorColor != ColorDefault {
+			p.separatorColor = t.SeparatorColor
+		}
+		if t.Position != nil {
+			p.position = *t.Position
+		}
+	}
+}
+
+// WithThemeFromEnv lets end users pick a look without the application
+// having to expose its own setting. It applies the fallback theme and layers
+// the user's choice on top of it: the theme named by the PIN_THEME
+// environment variable (a built-in name or a JSON file), or else the first
+// theme file found in ThemePaths. Invalid user themes are ignored; use
+// LoadTheme or LoadUserTheme to report them.
+func WithThemeFromEnv(fallback Theme) Option {
+	user, ok := envTheme()
+	return func(p *Pin) {
+		WithTheme(fallback)(p)
+		if ok {
+			WithTheme(user)(p)
+		}
+	}
+}
+
+// envTheme returns the theme selected by the user, if any.
+func envTheme() (Theme, bool) {
+	name := os.Getenv(ThemeEnv)
+	if name == "" {
+		t, ok, _ := LoadUserTheme()
+		return t, ok
+	}
+	if t, ok := ThemeByName(name); ok {
+		return t, true
+	}
+	if t, err := LoadTheme(name); err == nil {
+		return t, true
+	}
+	return Theme{}, false
+}
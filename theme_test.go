@@ -0,0 +1,154 @@
+package pin_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestWithThemeAppliesFramesAndSymbols(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf bytes.Buffer
+	p := pin.New("Themed", pin.WithWriter(&buf), pin.WithTheme(pin.ThemeASCII))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(200 * time.Millisecond)
+	p.Stop("Done")
+
+	output := buf.String()
+	found := false
+	for _, frame := range pin.ThemeASCII.Frames {
+		if strings.Contains(output, string(frame)) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("Expected output to contain one of the theme frames, got %q", output)
+	}
+	if !strings.Contains(output, string(pin.ThemeASCII.DoneSymbol)) {
+		t.Errorf("Expected output to contain theme done symbol %q, got %q", pin.ThemeASCII.DoneSymbol, output)
+	}
+}
+
+func TestOptionsAfterThemeOverrideIt(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf bytes.Buffer
+	p := pin.New("Themed",
+		pin.WithWriter(&buf),
+		pin.WithTheme(pin.ThemeASCII),
+		pin.WithDoneSymbol('!'),
+	)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(150 * time.Millisecond)
+	p.Stop("Done")
+
+	output := buf.String()
+	if !strings.Contains(output, "!") {
+		t.Errorf("Expected output to contain overriding done symbol '!', got %q", output)
+	}
+	if strings.Contains(output, string(pin.ThemeASCII.DoneSymbol)) {
+		t.Errorf("Expected theme done symbol to be overridden, got %q", output)
+	}
+}
+
+func TestThemeByName(t *testing.T) {
+	for _, theme := range pin.Themes() {
+		got, ok := pin.ThemeByName(strings.ToUpper(theme.Name))
+		if !ok {
+			t.Errorf("Expected built-in theme %q to be found", theme.Name)
+			continue
+		}
+		if got.Name != theme.Name {
+			t.Errorf("Expected theme %q, got %q", theme.Name, got.Name)
+		}
+	}
+	if _, ok := pin.ThemeByName("no-such-theme"); ok {
+		t.Error("Expected unknown theme lookup to fail")
+	}
+}
+
+func TestWithThemeFromEnv(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	old, had := os.LookupEnv(pin.ThemeEnv)
+	defer func() {
+		if had {
+			_ = os.Setenv(pin.ThemeEnv, old)
+		} else {
+			_ = os.Unsetenv(pin.ThemeEnv)
+		}
+	}()
+
+	_ = os.Setenv(pin.ThemeEnv, "ascii")
+	var buf bytes.Buffer
+	p := pin.New("Env", pin.WithWriter(&buf), pin.WithThemeFromEnv(pin.ThemeDots))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(150 * time.Millisecond)
+	p.Stop("Done")
+	if !strings.Contains(buf.String(), string(pin.ThemeASCII.DoneSymbol)) {
+		t.Errorf("Expected theme from environment to be applied, got %q", buf.String())
+	}
+
+	_ = os.Setenv(pin.ThemeEnv, "no-such-theme")
+	buf.Reset()
+	p = pin.New("Env", pin.WithWriter(&buf), pin.WithThemeFromEnv(pin.ThemeDots))
+	cancel = p.Start(context.Background())
+	defer cancel()
+	time.Sleep(150 * time.Millisecond)
+	p.Stop("Done")
+	if !strings.Contains(buf.String(), string(pin.ThemeDots.DoneSymbol)) {
+		t.Errorf("Expected fallback theme to be applied, got %q", buf.String())
+	}
+}
+
+func TestUserThemeCanSelectLeftPosition(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	f, err := ioutil.TempFile("", "pin-theme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	_, _ = f.WriteString(`{"position": "left"}`)
+	_ = f.Close()
+
+	old, had := os.LookupEnv(pin.ThemeEnv)
+	defer func() {
+		if had {
+			_ = os.Setenv(pin.ThemeEnv, old)
+		} else {
+			_ = os.Unsetenv(pin.ThemeEnv)
+		}
+	}()
+	_ = os.Setenv(pin.ThemeEnv, f.Name())
+
+	var buf bytes.Buffer
+	p := pin.New("Env", pin.WithWriter(&buf), pin.WithThemeFromEnv(pin.ThemeArc))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("Done")
+
+	output := buf.String()
+	if i, j := strings.Index(output, string(pin.ThemeArc.DoneSymbol)), strings.Index(output, "Done"); i < 0 || j < i {
+		t.Errorf("Expected the symbol before the message, got %q", output)
+	}
+}
@@ -0,0 +1,278 @@
+package pin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ThemeError reports an invalid theme configuration. Field names the
+// offending JSON field, e.g. "frames[2]" or "spinner_color".
+type ThemeError struct {
+	File  string
+	Field string
+	Err   error
+}
+
+func (e *ThemeError) Error() string {
+	msg := "pin: invalid theme"
+	if e.File != "" {
+		msg += " " + e.File
+	}
+	if e.Field != "" {
+		msg += ": field " + strconv.Quote(e.Field)
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+// themeFile is the JSON representation of a Theme.
+//
+// Example:
+//
+//	{
+//	  "frames": "⣾⣽⣻⢿⡿⣟⣯⣷",
+//	  "interval": "80ms",
+//	  "spinner_color": "#00afff",
+//	  "done_symbol": "●",
+//	  "done_symbol_color": "green",
+//	  "separator": "·",
+//	  "position": "left"
+//	}
+type themeFile struct {
+	Name            string          `json:"name"`
+	Frames          json.RawMessage `json:"frames"`
+	Interval        string          `json:"interval"`
+	SpinnerColor    string          `json:"spinner_color"`
+	TextColor       string          `json:"text_color"`
+	DoneSymbol      string          `json:"done_symbol"`
+	DoneSymbolColor string          `json:"done_symbol_color"`
+	FailSymbol      string          `json:"fail_symbol"`
+	FailSymbolColor string          `json:"fail_symbol_color"`
+	FailColor       string          `json:"fail_color"`
+	PrefixColor     string          `json:"prefix_color"`
+	Separator       string          `json:"separator"`
+	SeparatorColor  string          `json:"separator_color"`
+	Position        string          `json:"position"`
+}
+
+// ParseTheme parses a theme from JSON. Frames may be given as a string or as
+// an array of single-character strings, colors as names or hex values, and
+// the interval as a duration such as "80ms". Omitted fields are left zero.
+func ParseTheme(data []byte) (Theme, error) {
+	var f themeFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return Theme{}, decodeThemeError(err)
+	}
+	return f.theme()
+}
+
+// LoadTheme reads and parses a JSON theme file.
+func LoadTheme(path string) (Theme, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+	t, err := ParseTheme(data)
+	if te, ok := err.(*ThemeError); ok {
+		te.File = path
+	}
+	return t, err
+}
+
+// ThemePaths returns the locations searched by LoadUserTheme, in order:
+// $XDG_CONFIG_HOME/pin/theme.json (or ~/.config/pin/theme.json) followed by
+// pin/theme.json in each of $XDG_CONFIG_DIRS (or /etc/xdg).
+func ThemePaths() []string {
+	var paths []string
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		paths = append(paths, filepath.Join(dir, "pin", "theme.json"))
+	} else if home := os.Getenv("HOME"); home != "" {
+		paths = append(paths, filepath.Join(home, ".config", "pin", "theme.json"))
+	}
+	dirs := os.Getenv("XDG_CONFIG_DIRS")
+	if dirs == "" {
+		dirs = "/etc/xdg"
+	}
+	for _, dir := range filepath.SplitList(dirs) {
+		if dir != "" {
+			paths = append(paths, filepath.Join(dir, "pin", "theme.json"))
+		}
+	}
+	return paths
+}
+
+// LoadUserTheme loads the first theme file found in ThemePaths.
+// It returns false if no theme file exists.
+func LoadUserTheme() (Theme, bool, error) {
+	for _, path := range ThemePaths() {
+		t, err := LoadTheme(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		return t, err == nil, err
+	}
+	return Theme{}, false, nil
+}
+
+// theme converts the JSON representation into a Theme, validating each field.
+func (f *themeFile) theme() (Theme, error) {
+	t := Theme{Name: f.Name, Separator: f.Separator}
+	if strings.IndexFunc(f.Separator, unicode.IsControl) >= 0 {
+		return Theme{}, &ThemeError{Field: "separator", Err: errors.New("must not contain control characters")}
+	}
+
+	frames, err := parseFrames(f.Frames)
+	if err != nil {
+		return Theme{}, err
+	}
+	t.Frames = frames
+
+	if f.Interval != "" {
+		d, err := time.ParseDuration(f.Interval)
+		if err != nil {
+			return Theme{}, &ThemeError{Field: "interval", Err: err}
+		}
+		if d <= 0 {
+			return Theme{}, &ThemeError{Field: "interval", Err: errors.New("must be positive")}
+		}
+		t.Interval = d
+	}
+
+	colors := []struct {
+		field string
+		value string
+		dst   *Color
+	}{
+		{"spinner_color", f.SpinnerColor, &t.SpinnerColor},
+		{"text_color", f.TextColor, &t.TextColor},
+		{"done_symbol_color", f.DoneSymbolColor, &t.DoneSymbolColor},
+		{"fail_symbol_color", f.FailSymbolColor, &t.FailSymbolColor},
+		{"fail_color", f.FailColor, &t.FailColor},
+		{"prefix_color", f.PrefixColor, &t.PrefixColor},
+		{"separator_color", f.SeparatorColor, &t.SeparatorColor},
+	}
+	for _, c := range colors {
+		if c.value == "" {
+			continue
+		}
+		color, err := ParseColor(c.value)
+		if err != nil {
+			return Theme{}, &ThemeError{Field: c.field, Err: err}
+		}
+		*c.dst = color
+	}
+
+	symbols := []struct {
+		field string
+		value string
+		dst   *rune
+	}{
+		{"done_symbol", f.DoneSymbol, &t.DoneSymbol},
+		{"fail_symbol", f.FailSymbol, &t.FailSymbol},
+	}
+	for _, s := range symbols {
+		if s.value == "" {
+			continue
+		}
+		r, err := parseSymbol(s.value)
+		if err != nil {
+			return Theme{}, &ThemeError{Field: s.field, Err: err}
+		}
+		*s.dst = r
+	}
+
+	switch strings.ToLower(f.Position) {
+	case "":
+	case "left":
+		t.Position = positionOf(PositionLeft)
+	case "right":
+		t.Position = positionOf(PositionRight)
+	default:
+		return Theme{}, &ThemeError{Field: "position", Err: fmt.Errorf("must be \"left\" or \"right\", got %q", f.Position)}
+	}
+
+	return t, nil
+}
+
+// parseFrames accepts either a string of frames or an array of
+// single-character strings.
+func parseFrames(raw json.RawMessage) ([]rune, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var frames []rune
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, &ThemeError{Field: "frames", Err: err}
+		}
+		frames = []rune(s)
+		for i, r := range frames {
+			if unicode.IsControl(r) {
+				return nil, &ThemeError{Field: fmt.Sprintf("frames[%d]", i), Err: fmt.Errorf("must not be a control character, got %q", r)}
+			}
+		}
+	} else {
+		var list []string
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return nil, &ThemeError{Field: "frames", Err: errors.New("must be a string or an array of strings")}
+		}
+		for i, s := range list {
+			r, err := parseSymbol(s)
+			if err != nil {
+				return nil, &ThemeError{Field: fmt.Sprintf("frames[%d]", i), Err: err}
+			}
+			frames = append(frames, r)
+		}
+	}
+	if len(frames) == 0 {
+		return nil, &ThemeError{Field: "frames", Err: errors.New("must not be empty")}
+	}
+	return frames, nil
+}
+
+// parseSymbol returns the single character in s, which must be printable.
+func parseSymbol(s string) (rune, error) {
+	if utf8.RuneCountInString(s) != 1 {
+		return 0, fmt.Errorf("must be a single character, got %q", s)
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	if unicode.IsControl(r) {
+		return 0, fmt.Errorf("must not be a control character, got %q", s)
+	}
+	return r, nil
+}
+
+// decodeThemeError converts errors from encoding/json into a ThemeError.
+func decodeThemeError(err error) error {
+	switch e := err.(type) {
+	case *json.UnmarshalTypeError:
+		if e.Field == "" {
+			return &ThemeError{Err: fmt.Errorf("must be a JSON object, got %s", e.Value)}
+		}
+		return &ThemeError{Field: e.Field, Err: fmt.Errorf("must be a string, got %s", e.Value)}
+	case *json.SyntaxError:
+		return &ThemeError{Err: fmt.Errorf("%v (at offset %d)", e, e.Offset)}
+	}
+	const unknown = "json: unknown field "
+	if msg := err.Error(); strings.HasPrefix(msg, unknown) {
+		field, qerr := strconv.Unquote(strings.TrimPrefix(msg, unknown))
+		if qerr == nil {
+			return &ThemeError{Field: field, Err: errors.New("unknown field")}
+		}
+	}
+	return &ThemeError{Err: err}
+}
@@ -0,0 +1,150 @@
+package pin_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestParseTheme(t *testing.T) {
+	data := []byte(`{
+		"name": "custom",
+		"frames": "abc",
+		"interval": "80ms",
+		"spinner_color": "#00afff",
+		"done_symbol": "●",
+		"done_symbol_color": "green",
+		"separator": "·",
+		"position": "right"
+	}`)
+	theme, err := pin.ParseTheme(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(theme.Frames) != "abc" {
+		t.Errorf("Expected frames %q, got %q", "abc", string(theme.Frames))
+	}
+	if theme.Interval != 80*time.Millisecond {
+		t.Errorf("Expected interval 80ms, got %v", theme.Interval)
+	}
+	if theme.SpinnerColor != pin.RGB(0x00, 0xaf, 0xff) {
+		t.Errorf("Unexpected spinner color %v", theme.SpinnerColor)
+	}
+	if theme.DoneSymbol != '●' || theme.DoneSymbolColor != pin.ColorGreen {
+		t.Errorf("Unexpected done symbol %q with color %v", theme.DoneSymbol, theme.DoneSymbolColor)
+	}
+	if theme.Position == nil || *theme.Position != pin.PositionRight {
+		t.Errorf("Expected position right, got %v", theme.Position)
+	}
+}
+
+func TestParseThemeFramesArray(t *testing.T) {
+	theme, err := pin.ParseTheme([]byte(`{"frames": ["|", "/", "-", "\\"]}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(theme.Frames) != `|/-\` {
+		t.Errorf("Unexpected frames %q", string(theme.Frames))
+	}
+}
+
+func TestParseThemeWithoutPosition(t *testing.T) {
+	theme, err := pin.ParseTheme([]byte(`{"frames": "abc"}`))
+	if err != nil {
+		t.Fatalf("ParseTheme failed: %v", err)
+	}
+	if theme.Position != nil {
+		t.Errorf("Expected an omitted position to be unset, got %v", *theme.Position)
+	}
+}
+
+func TestParseThemeErrorsNameField(t *testing.T) {
+	tests := []struct {
+		data  string
+		field string
+	}{
+		{`{"frames": ""}`, "frames"},
+		{`{"frames": ["a", "bc"]}`, "frames[1]"},
+		{`{"interval": "fast"}`, "interval"},
+		{`{"interval": "-1s"}`, "interval"},
+		{`{"spinner_color": "purple"}`, "spinner_color"},
+		{`{"done_symbol": "ok"}`, "done_symbol"},
+		{`{"done_symbol": "\u001b"}`, "done_symbol"},
+		{`{"fail_symbol": "\u009b"}`, "fail_symbol"},
+		{`{"frames": ["\n", "a"]}`, "frames[0]"},
+		{`{"frames": "ab\u0007"}`, "frames[2]"},
+		{`{"position": "top"}`, "position"},
+		{`{"separator": "\u001b]0;pwned\u0007"}`, "separator"},
+		{`{"text_color": 3}`, "text_color"},
+		{`{"colour": "red"}`, "colour"},
+	}
+	for _, tt := range tests {
+		_, err := pin.ParseTheme([]byte(tt.data))
+		te, ok := err.(*pin.ThemeError)
+		if !ok {
+			t.Errorf("ParseTheme(%s): expected *ThemeError, got %v", tt.data, err)
+			continue
+		}
+		if te.Field != tt.field {
+			t.Errorf("ParseTheme(%s): expected field %q, got %q (%v)", tt.data, tt.field, te.Field, err)
+		}
+	}
+}
+
+func TestLoadThemeReportsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "theme.json")
+	if err := ioutil.WriteFile(path, []byte(`{"fail_color": "nope"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = pin.LoadTheme(path)
+	if err == nil || !strings.Contains(err.Error(), path) || !strings.Contains(err.Error(), "fail_color") {
+		t.Errorf("Expected error naming file and field, got %v", err)
+	}
+}
+
+func TestLoadUserThemeFromXDGConfigHome(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	old, had := os.LookupEnv("XDG_CONFIG_HOME")
+	defer func() {
+		if had {
+			_ = os.Setenv("XDG_CONFIG_HOME", old)
+		} else {
+			_ = os.Unsetenv("XDG_CONFIG_HOME")
+		}
+	}()
+	_ = os.Setenv("XDG_CONFIG_HOME", dir)
+
+	if paths := pin.ThemePaths(); len(paths) == 0 || paths[0] != filepath.Join(dir, "pin", "theme.json") {
+		t.Fatalf("Expected first theme path under XDG_CONFIG_HOME, got %v", paths)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "pin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "pin", "theme.json"), []byte(`{"done_symbol": "!"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	theme, ok, err := pin.LoadUserTheme()
+	if err != nil || !ok {
+		t.Fatalf("Expected user theme to load, got ok=%v err=%v", ok, err)
+	}
+	if theme.DoneSymbol != '!' {
+		t.Errorf("Expected done symbol '!', got %q", theme.DoneSymbol)
+	}
+}
@@ -0,0 +1,45 @@
+package pin
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithTimeout fails the spinner if it is still running after d. The failure
+// message is msg or, if msg is empty, "timed out after <d>".
+//
+// A deadline on the context passed to Start is handled the same way, so
+// that a timeout is reported as a failure while a cancellation just clears
+// the spinner line.
+//
+// Example usage:
+//
+//	p := pin.New("Waiting for healthy status", pin.WithTimeout(30*time.Second, ""))
+func WithTimeout(d time.Duration, msg string) Option {
+	return func(p *Pin) {
+		p.timeout = d
+		p.timeoutMsg = msg
+	}
+}
+
+// timeoutMessage returns the failure message for a run whose deadline expired.
+// The caller must hold p.mu.
+func (p *Pin) timeoutMessage() string {
+	if p.timeoutMsg != "" {
+		return p.timeoutMsg
+	}
+	d := p.timeout
+	if deadline, ok := p.runCtx.Deadline(); ok {
+		d = deadline.Sub(p.started)
+	}
+	return fmt.Sprintf("timed out after %v", roundDuration(d))
+}
+
+// roundDuration rounds d for display: to seconds from one second up and to
+// milliseconds below that.
+func roundDuration(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(time.Second)
+	}
+	return d.Round(time.Millisecond)
+}
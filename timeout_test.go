@@ -0,0 +1,108 @@
+package pin_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+// waitStopped waits until the spinner is no longer running.
+func waitStopped(t *testing.T, p *pin.Pin) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for p.IsRunning() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the spinner to stop")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWithTimeoutFailsSpinner(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Waiting", pin.WithWriter(&buf), pin.WithTimeout(50*time.Millisecond, ""))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	waitStopped(t, p)
+
+	output := buf.String()
+	if !strings.Contains(output, "✖") || !strings.Contains(output, "timed out after 50ms") {
+		t.Errorf("Expected timeout failure in output, got %q", output)
+	}
+}
+
+func TestWithTimeoutCustomMessage(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Waiting", pin.WithWriter(&buf), pin.WithTimeout(20*time.Millisecond, "service never became healthy"))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	waitStopped(t, p)
+
+	if got, want := buf.String(), "Waiting\nservice never became healthy\n"; got != want {
+		t.Errorf("Expected output %q, got %q", want, got)
+	}
+}
+
+func TestContextDeadlineFailsSpinner(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancelCtx()
+
+	var buf syncBuffer
+	p := pin.New("Waiting", pin.WithWriter(&buf))
+	cancel := p.Start(ctx)
+	defer cancel()
+	waitStopped(t, p)
+
+	// The deadline is measured from Start, which runs just after the
+	// context is created.
+	output := buf.String()
+	i := strings.Index(output, "timed out after ")
+	if i < 0 {
+		t.Fatalf("Expected deadline to be reported as a timeout, got %q", output)
+	}
+	field := strings.Fields(output[i+len("timed out after "):])[0]
+	d, err := time.ParseDuration(strings.TrimSuffix(field, "\x1b[0m"))
+	if err != nil || d < 450*time.Millisecond || d > 500*time.Millisecond {
+		t.Errorf("Expected a timeout of about 500ms, got %q", output)
+	}
+}
+
+func TestCancellationIsNotATimeout(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Waiting", pin.WithWriter(&buf), pin.WithTimeout(time.Hour, ""))
+	cancel := p.Start(context.Background())
+	cancel()
+	waitStopped(t, p)
+
+	if output := buf.String(); strings.Contains(output, "timed out") || strings.Contains(output, "✖") {
+		t.Errorf("Expected cancellation to clear the line without failing, got %q", output)
+	}
+}
+
+func TestStopBeforeTimeout(t *testing.T) {
+	var buf syncBuffer
+	p := pin.New("Waiting", pin.WithWriter(&buf), pin.WithTimeout(50*time.Millisecond, ""))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("Done")
+	time.Sleep(100 * time.Millisecond)
+
+	if output := buf.String(); strings.Contains(output, "timed out") {
+		t.Errorf("Expected no timeout after Stop, got %q", output)
+	}
+}
@@ -0,0 +1,148 @@
+package pin
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	escPushTitle = "\033[22;0t"
+	escPopTitle  = "\033[23;0t"
+)
+
+// States of the OSC 9;4 progress sequence.
+const (
+	progressNone          = 0
+	progressNormal        = 1
+	progressError         = 2
+	progressIndeterminate = 3
+	progressPaused        = 4
+)
+
+// WithTerminalTitle shows the spinner's message, and its progress if set
+// with SetProgress, in the terminal title while the spinner runs, so that
+// long operations can be followed from a background tab. The previous title
+// is restored when the spinner finishes. It has no effect in non-interactive
+// mode.
+func WithTerminalTitle() Option {
+	return func(p *Pin) {
+		p.title = true
+	}
+}
+
+// WithTaskbarProgress reports the spinner's state with the OSC 9;4
+// sequence, which terminals such as Windows Terminal, ConEmu and iTerm2 show
+// as a progress indicator in the tab or taskbar: indeterminate while
+// spinning, the percentage set with SetProgress, paused while the spinner is
+// paused and an error state after Fail. It has no effect in non-interactive
+// mode.
+//
+// Example usage:
+//
+//	p := pin.New("Downloading", pin.WithTerminalTitle(), pin.WithTaskbarProgress())
+//	cancel := p.Start(ctx)
+//	defer cancel()
+//	for i, f := range files {
+//	    p.SetProgress(100 * i / len(files))
+//	    download(f)
+//	}
+//	p.Stop("Downloaded")
+func WithTaskbarProgress() Option {
+	return func(p *Pin) {
+		p.taskbar = true
+	}
+}
+
+// SetProgress sets the completed percentage of the task, shown in the
+// terminal title and taskbar if enabled. Values are clamped to 100; a
+// negative value switches back to indeterminate progress. Progress set before
+// Start is shown once the spinner starts; it is reset when the run finishes.
+func (p *Pin) SetProgress(percent int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if percent > 100 {
+		percent = 100
+	}
+	if percent < 0 {
+		percent = -1
+	}
+	p.progress = percent
+	if !p.active() {
+		return
+	}
+	p.touch()
+	p.updateTitle()
+	if p.state == StateRunning {
+		p.updateProgress(progressNormal)
+	}
+}
+
+// header returns the prefix and message as plain text on a single line.
+// The caller must hold p.mu.
+func (p *Pin) header() string {
+	header := p.clean(p.message)
+	if p.prefix != "" {
+		header = p.clean(p.prefix) + " " + p.clean(p.separator) + " " + header
+	}
+	return strings.Replace(header, "\n", " ", -1)
+}
+
+// startTitle saves the terminal title and shows the spinner in it.
+// The caller must hold p.mu.
+func (p *Pin) startTitle() {
+	if !p.title || !p.interactive {
+		return
+	}
+	_, _ = fmt.Fprint(p.out, escPushTitle)
+	p.titleSet, p.lastTitle = true, ""
+	p.updateTitle()
+}
+
+// updateTitle shows the current message and progress in the terminal title
+// if they changed. The caller must hold p.mu.
+func (p *Pin) updateTitle() {
+	if !p.titleSet {
+		return
+	}
+	title := p.header()
+	if p.progress >= 0 {
+		title = fmt.Sprintf("%s (%d%%)", title, p.progress)
+	}
+	if title == p.lastTitle {
+		return
+	}
+	_, _ = fmt.Fprintf(p.out, "\033]0;%s\a", title)
+	p.lastTitle = title
+}
+
+// restoreTitle restores the title saved by startTitle.
+// The caller must hold p.mu.
+func (p *Pin) restoreTitle() {
+	if !p.titleSet {
+		return
+	}
+	_, _ = fmt.Fprint(p.out, escPopTitle)
+	p.titleSet = false
+}
+
+// updateProgress reports the given progress state. Normal progress is
+// reported as indeterminate until a percentage is set.
+// The caller must hold p.mu.
+func (p *Pin) updateProgress(state int) {
+	if !p.taskbar || !p.interactive {
+		return
+	}
+	if state == progressNormal && p.progress < 0 {
+		state = progressIndeterminate
+	}
+	switch state {
+	case progressNone, progressIndeterminate:
+		_, _ = fmt.Fprintf(p.out, "\033]9;4;%d\a", state)
+	default:
+		percent := p.progress
+		if percent < 0 {
+			percent = 100
+		}
+		_, _ = fmt.Fprintf(p.out, "\033]9;4;%d;%d\a", state, percent)
+	}
+}
@@ -0,0 +1,118 @@
+package pin_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yarlson/pin"
+)
+
+// escapes returns the OSC and title stack sequences written to out, in order.
+func escapes(out string) []string {
+	var seqs []string
+	for i := 0; i < len(out); i++ {
+		switch {
+		case strings.HasPrefix(out[i:], "\033]"):
+			end := strings.IndexByte(out[i:], '\a')
+			if end < 0 {
+				return seqs
+			}
+			seqs = append(seqs, out[i:i+end+1])
+			i += end
+		case strings.HasPrefix(out[i:], "\033[22;0t"), strings.HasPrefix(out[i:], "\033[23;0t"):
+			seqs = append(seqs, out[i:i+7])
+			i += 6
+		}
+	}
+	return seqs
+}
+
+func TestTerminalTitleAndProgress(t *testing.T) {
+	// Force interactive mode.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf syncBuffer
+	p := pin.New("Downloading", pin.WithWriter(&buf), pin.WithPrefix("npm"), pin.WithTerminalTitle(), pin.WithTaskbarProgress())
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.UpdateMessage("Downloading\nlodash")
+	p.SetProgress(40)
+	p.SetProgress(40)
+	p.Pause()
+	p.Resume()
+	p.Stop("Downloaded")
+
+	want := []string{
+		"\033[22;0t",
+		"\033]0;npm › Downloading\a",
+		"\033]9;4;3\a",
+		"\033]0;npm › Downloading lodash\a",
+		"\033]0;npm › Downloading lodash (40%)\a",
+		"\033]9;4;1;40\a",
+		"\033]9;4;1;40\a",
+		"\033]9;4;4;40\a",
+		"\033]9;4;1;40\a",
+		"\033]9;4;0\a",
+		"\033[23;0t",
+	}
+	if got := escapes(buf.String()); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected sequences %q, got %q", want, got)
+	}
+}
+
+func TestTaskbarProgressErrorOnFail(t *testing.T) {
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf bytes.Buffer
+	p := pin.New("Building", pin.WithWriter(&buf), pin.WithTaskbarProgress())
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Fail("Build failed")
+
+	if got := escapes(buf.String()); len(got) != 2 || got[1] != "\033]9;4;2;100\a" {
+		t.Errorf("Expected an error state, got %q", got)
+	}
+}
+
+func TestProgressSetBeforeStart(t *testing.T) {
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf bytes.Buffer
+	p := pin.New("Building", pin.WithWriter(&buf), pin.WithTerminalTitle(), pin.WithTaskbarProgress())
+	p.SetProgress(25)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop()
+
+	want := []string{"\033[22;0t", "\033]0;Building (25%)\a", "\033]9;4;1;25\a", "\033]9;4;0\a", "\033[23;0t"}
+	if got := escapes(buf.String()); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected sequences %q, got %q", want, got)
+	}
+
+	// The progress is reset when the run finishes.
+	buf.Reset()
+	cancel = p.Start(context.Background())
+	defer cancel()
+	p.Stop()
+	if got := escapes(buf.String()); len(got) < 3 || got[2] != "\033]9;4;3\a" {
+		t.Errorf("Expected indeterminate progress on the next run, got %q", got)
+	}
+}
+
+func TestTitleAndProgressNeedATerminal(t *testing.T) {
+	var buf bytes.Buffer
+	p := pin.New("Building", pin.WithWriter(&buf), pin.WithTerminalTitle(), pin.WithTaskbarProgress())
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.SetProgress(50)
+	p.Stop("Built")
+
+	if got := buf.String(); got != "Building\nBuilt\n" {
+		t.Errorf("Expected no escape sequences, got %q", got)
+	}
+}
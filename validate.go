@@ -0,0 +1,93 @@
+package pin
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConfigError reports an invalid spinner setting.
+type ConfigError struct {
+	Field string
+	Err   error
+}
+
+func (e *ConfigError) Error() string {
+	return "pin: invalid " + e.Field + ": " + e.Err.Error()
+}
+
+// Validate reports the first invalid setting of the spinner, such as empty
+// frames, a nil writer, a non-positive interval or an unknown color.
+func (p *Pin) Validate() error {
+	return p.checkConfig(false)
+}
+
+// checkConfig returns an error for the first invalid setting. If fix is true,
+// it instead replaces every invalid setting with its built-in default.
+func (p *Pin) checkConfig(fix bool) error {
+	d := basePin("")
+
+	if len(p.frames) == 0 {
+		if !fix {
+			return &ConfigError{Field: "frames", Err: errors.New("must not be empty")}
+		}
+		p.frames = d.frames
+	}
+	if p.interval <= 0 {
+		if !fix {
+			return &ConfigError{Field: "interval", Err: fmt.Errorf("must be positive, got %v", p.interval)}
+		}
+		p.interval = d.interval
+	}
+	if p.out == nil {
+		if !fix {
+			return &ConfigError{Field: "writer", Err: errors.New("must not be nil")}
+		}
+		p.out = d.out
+	}
+	if p.position != PositionLeft && p.position != PositionRight {
+		if !fix {
+			return &ConfigError{Field: "position", Err: fmt.Errorf("unknown position %d", p.position)}
+		}
+		p.position = d.position
+	}
+	if p.truncation < TruncateEnd || p.truncation > TruncateNone {
+		if !fix {
+			return &ConfigError{Field: "truncation", Err: fmt.Errorf("unknown truncation mode %d", p.truncation)}
+		}
+		p.truncation = d.truncation
+	}
+
+	colors := []struct {
+		field string
+		color *Color
+		def   Color
+	}{
+		{"spinner color", &p.spinnerColor, d.spinnerColor},
+		{"text color", &p.textColor, d.textColor},
+		{"done symbol color", &p.doneSymbolColor, d.doneSymbolColor},
+		{"fail symbol color", &p.failSymbolColor, d.failSymbolColor},
+		{"fail color", &p.failColor, d.failColor},
+		{"prefix color", &p.prefixColor, d.prefixColor},
+		{"separator color", &p.separatorColor, d.separatorColor},
+		{"interrupt symbol color", &p.interruptSymbolColor, d.interruptSymbolColor},
+		{"warning color", &p.warningColor, d.warningColor},
+	}
+	for _, c := range colors {
+		if c.color.valid() {
+			continue
+		}
+		if !fix {
+			return &ConfigError{Field: c.field, Err: fmt.Errorf("unknown color %d", *c.color)}
+		}
+		*c.color = c.def
+	}
+	return nil
+}
+
+// valid reports whether the color is one of the predefined colors or an RGB color.
+func (c Color) valid() bool {
+	if c.isRGB() {
+		return c < colorRGB<<1
+	}
+	return c >= ColorDefault && c <= ColorReset
+}
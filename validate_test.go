@@ -0,0 +1,78 @@
+package pin_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+func TestNewEReportsInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		opt   pin.Option
+		field string
+	}{
+		{"empty frames", pin.WithSpinnerFrames([]rune{}), "frames"},
+		{"nil writer", pin.WithWriter(nil), "writer"},
+		{"zero interval", pin.WithInterval(0), "interval"},
+		{"unknown color", pin.WithTextColor(pin.Color(42)), "text color"},
+		{"unknown position", pin.WithPosition(pin.Position(7)), "position"},
+	}
+	for _, tt := range tests {
+		p, err := pin.NewE("Invalid", tt.opt)
+		if p != nil {
+			t.Errorf("%s: expected nil spinner", tt.name)
+		}
+		ce, ok := err.(*pin.ConfigError)
+		if !ok {
+			t.Errorf("%s: expected *ConfigError, got %v", tt.name, err)
+			continue
+		}
+		if ce.Field != tt.field {
+			t.Errorf("%s: expected field %q, got %q", tt.name, tt.field, ce.Field)
+		}
+	}
+}
+
+func TestNewEAcceptsValidOptions(t *testing.T) {
+	var buf bytes.Buffer
+	p, err := pin.NewE("Valid",
+		pin.WithWriter(&buf),
+		pin.WithSpinnerColor(pin.RGB(1, 2, 3)),
+		pin.WithInterval(50*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("Expected Validate to succeed, got %v", err)
+	}
+}
+
+func TestNewFallsBackToDefaults(t *testing.T) {
+	// Force interactive mode so that the render loop runs.
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+
+	var buf bytes.Buffer
+	p := pin.New("Fallback",
+		pin.WithWriter(&buf),
+		pin.WithSpinnerFrames(nil),
+		pin.WithInterval(-time.Second),
+		pin.WithSpinnerColor(pin.Color(-1)),
+	)
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Expected New to repair invalid settings, got %v", err)
+	}
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(150 * time.Millisecond)
+	p.Stop("Done")
+	if !strings.Contains(buf.String(), "⠋") {
+		t.Errorf("Expected default frames in output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,262 @@
+package pin
+
+import (
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Truncation controls how messages wider than the terminal are shortened.
+//
+// Example usage:
+//
+//	p := pin.New("Copying /var/lib/app/data/file.db", pin.WithTruncation(pin.TruncateMiddle))
+type Truncation int
+
+const (
+	TruncateEnd    Truncation = iota // Cut the end of the message (default)
+	TruncateMiddle                   // Cut the middle, keeping both ends, e.g. for file paths
+	TruncateNone                     // Never truncate
+)
+
+// ellipsis marks the place where a message was truncated.
+const ellipsis = "…"
+
+// WithTruncation sets how messages wider than the terminal are shortened so
+// that the spinner line does not wrap. Final messages printed by Stop and
+// Fail are never truncated.
+func WithTruncation(mode Truncation) Option {
+	return func(p *Pin) {
+		p.truncation = mode
+	}
+}
+
+// terminalWidth returns the number of columns of the terminal w writes to,
+// falling back to $COLUMNS. It returns 0 if the width is unknown.
+func terminalWidth(w io.Writer) int {
+	cols, _ := terminalSize(w)
+	return cols
+}
+
+// terminalSize returns the number of columns and rows of the terminal w
+// writes to, falling back to $COLUMNS and $LINES. Unknown sizes are 0.
+func terminalSize(w io.Writer) (cols, rows int) {
+	if b, ok := w.(*StatusBar); ok {
+		w = b.out
+	}
+	if f, ok := w.(*os.File); ok {
+		cols, rows = fileSize(f)
+	}
+	if cols <= 0 {
+		cols = envSize("COLUMNS")
+	}
+	if rows <= 0 {
+		rows = envSize("LINES")
+	}
+	return cols, rows
+}
+
+// envSize returns the positive number in the environment variable name, or 0.
+func envSize(name string) int {
+	if n, err := strconv.Atoi(os.Getenv(name)); err == nil && n > 0 {
+		return n
+	}
+	return 0
+}
+
+// segment is a piece of a string as it appears on the terminal: a single
+// rune or an escape sequence, which takes no space.
+type segment struct {
+	text  string
+	width int
+}
+
+// segments splits s into runes and escape sequences and computes their
+// display widths. A rune joined to the previous one by a zero width joiner,
+// as in emoji sequences, takes no additional space.
+func segments(s string) []segment {
+	var segs []segment
+	joined := false
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			segs = append(segs, segment{text: s[i : i+n]})
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := runeWidth(r)
+		if joined {
+			w = 0
+		}
+		joined = r == '\u200d' // zero width joiner
+		segs = append(segs, segment{text: s[i : i+size], width: w})
+		i += size
+	}
+	return segs
+}
+
+// escapeLen returns the length of the CSI or OSC escape sequence at the
+// start of s, or 0 if s does not start with one.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != '\033' {
+		return 0
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\033' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	}
+	return 0
+}
+
+// stringWidth returns the number of columns s occupies on the terminal.
+func stringWidth(s string) int {
+	n := 0
+	for _, seg := range segments(s) {
+		n += seg.width
+	}
+	return n
+}
+
+// truncate shortens s to at most width columns according to mode, marking
+// the cut with an ellipsis. Escape sequences are kept.
+func truncate(s string, width int, mode Truncation) string {
+	if mode == TruncateNone || stringWidth(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	segs := segments(s)
+	avail := width - 1 // room for the ellipsis
+	headWidth := avail
+	if mode == TruncateMiddle {
+		headWidth = avail - avail/2
+	}
+
+	var b strings.Builder
+	used, i := 0, 0
+	for ; i < len(segs); i++ {
+		if used+segs[i].width > headWidth {
+			break
+		}
+		used += segs[i].width
+		b.WriteString(segs[i].text)
+	}
+	// Keep escape sequences after the cut, e.g. a color reset.
+	var rest strings.Builder
+	for _, seg := range segs[i:] {
+		if isEscape(seg) {
+			rest.WriteString(seg.text)
+		}
+	}
+	if mode != TruncateMiddle {
+		return b.String() + ellipsis + rest.String()
+	}
+
+	tailWidth := avail - used
+	j := len(segs)
+	used = 0
+	for j > i {
+		seg := segs[j-1]
+		if used+seg.width > tailWidth {
+			break
+		}
+		used += seg.width
+		j--
+	}
+	// Do not start the tail with combining marks whose base was cut.
+	for j < len(segs) && segs[j].width == 0 && !isEscape(segs[j]) {
+		j++
+	}
+	for _, seg := range segs[i:j] {
+		if isEscape(seg) {
+			b.WriteString(seg.text)
+		}
+	}
+	b.WriteString(ellipsis)
+	for _, seg := range segs[j:] {
+		b.WriteString(seg.text)
+	}
+	return b.String()
+}
+
+// isEscape reports whether seg is an escape sequence.
+func isEscape(seg segment) bool {
+	return len(seg.text) > 1 && seg.text[0] == '\033'
+}
+
+// runeWidth returns the number of columns r occupies on the terminal:
+// 0 for control characters, combining marks and other invisible runes,
+// 2 for East Asian wide and fullwidth characters and emoji, 1 otherwise.
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0):
+		return 0
+	case r < 0x300:
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf),
+		r >= 0x1160 && r <= 0x11ff, // Hangul medial vowels and final consonants
+		r >= 0xfe00 && r <= 0xfe0f, // variation selectors
+		r >= 0xe0100 && r <= 0xe01ef:
+		return 0
+	case inRanges(r, wideRanges):
+		return 2
+	}
+	return 1
+}
+
+// runeRange is an inclusive range of runes.
+type runeRange struct {
+	lo, hi rune
+}
+
+// inRanges reports whether r is in one of the sorted ranges.
+func inRanges(r rune, ranges []runeRange) bool {
+	i := sort.Search(len(ranges), func(i int) bool { return ranges[i].hi >= r })
+	return i < len(ranges) && ranges[i].lo <= r
+}
+
+// wideRanges lists the East Asian Wide and Fullwidth characters and the
+// emoji displayed in two columns by default.
+var wideRanges = []runeRange{
+	{0x1100, 0x115f}, {0x231a, 0x231b}, {0x2329, 0x232a}, {0x23e9, 0x23ec},
+	{0x23f0, 0x23f0}, {0x23f3, 0x23f3}, {0x25fd, 0x25fe}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267f, 0x267f}, {0x2693, 0x2693}, {0x26a1, 0x26a1},
+	{0x26aa, 0x26ab}, {0x26bd, 0x26be}, {0x26c4, 0x26c5}, {0x26ce, 0x26ce},
+	{0x26d4, 0x26d4}, {0x26ea, 0x26ea}, {0x26f2, 0x26f3}, {0x26f5, 0x26f5},
+	{0x26fa, 0x26fa}, {0x26fd, 0x26fd}, {0x2705, 0x2705}, {0x270a, 0x270b},
+	{0x2728, 0x2728}, {0x274c, 0x274c}, {0x274e, 0x274e}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27b0, 0x27b0}, {0x27bf, 0x27bf},
+	{0x2b1b, 0x2b1c}, {0x2b50, 0x2b50}, {0x2b55, 0x2b55}, {0x2e80, 0x303e},
+	{0x3041, 0x33ff}, {0x3400, 0x4dbf}, {0x4e00, 0x9fff}, {0xa000, 0xa4cf},
+	{0xa960, 0xa97f}, {0xac00, 0xd7a3}, {0xf900, 0xfaff}, {0xfe10, 0xfe19},
+	{0xfe30, 0xfe6f}, {0xff00, 0xff60}, {0xffe0, 0xffe6}, {0x16fe0, 0x16fe4},
+	{0x17000, 0x18cff}, {0x1b000, 0x1b2ff}, {0x1f004, 0x1f004}, {0x1f0cf, 0x1f0cf},
+	{0x1f18e, 0x1f18e}, {0x1f191, 0x1f19a}, {0x1f200, 0x1f251}, {0x1f300, 0x1f320},
+	{0x1f32d, 0x1f335}, {0x1f337, 0x1f37c}, {0x1f37e, 0x1f393}, {0x1f3a0, 0x1f3ca},
+	{0x1f3cf, 0x1f3d3}, {0x1f3e0, 0x1f3f0}, {0x1f3f4, 0x1f3f4}, {0x1f3f8, 0x1f43e},
+	{0x1f440, 0x1f440}, {0x1f442, 0x1f4fc}, {0x1f4ff, 0x1f53d}, {0x1f54b, 0x1f54e},
+	{0x1f550, 0x1f567}, {0x1f57a, 0x1f57a}, {0x1f595, 0x1f596}, {0x1f5a4, 0x1f5a4},
+	{0x1f5fb, 0x1f64f}, {0x1f680, 0x1f6c5}, {0x1f6cc, 0x1f6cc}, {0x1f6d0, 0x1f6d2},
+	{0x1f6d5, 0x1f6d7}, {0x1f6dc, 0x1f6df}, {0x1f6eb, 0x1f6ec}, {0x1f6f4, 0x1f6fc},
+	{0x1f7e0, 0x1f7eb}, {0x1f7f0, 0x1f7f0}, {0x1f90c, 0x1f93a}, {0x1f93c, 0x1f945},
+	{0x1f947, 0x1f9ff}, {0x1fa70, 0x1faff}, {0x20000, 0x2fffd}, {0x30000, 0x3fffd},
+}
@@ -0,0 +1,91 @@
+package pin_test
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/pin"
+)
+
+var (
+	sgr       = regexp.MustCompile("\x1b\\[[0-9;]*m")
+	clearLine = regexp.MustCompile("\r\x1b\\[K(\x1b\\[1A\x1b\\[K)*")
+)
+
+// lastFrame returns the visible text of the last frame drawn to out.
+func lastFrame(out string) string {
+	frames := clearLine.Split(out, -1)
+	for i := len(frames) - 1; i >= 0; i-- {
+		if frames[i] != "" {
+			return sgr.ReplaceAllString(frames[i], "")
+		}
+	}
+	return ""
+}
+
+// renderFrame draws a frame of a spinner in a terminal of the given width.
+func renderFrame(t *testing.T, columns, message string, opts ...pin.Option) string {
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+	_ = os.Setenv("COLUMNS", columns)
+	defer os.Unsetenv("COLUMNS")
+
+	var buf syncBuffer
+	opts = append([]pin.Option{pin.WithWriter(&buf), pin.WithSpinnerFrames([]rune{'*'}), pin.WithInterval(5 * time.Millisecond)}, opts...)
+	p := pin.New(message, opts...)
+	cancel := p.Start(context.Background())
+	defer cancel()
+	time.Sleep(30 * time.Millisecond)
+	p.Stop()
+	return lastFrame(buf.String())
+}
+
+func TestTruncatesToTerminalWidth(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns string
+		message string
+		opts    []pin.Option
+		want    string
+	}{
+		{"fits", "20", "Building", nil, "* Building"},
+		{"end", "12", "Downloading packages", nil, "* Download…"},
+		{"middle", "16", "/var/lib/app/data.db", []pin.Option{pin.WithTruncation(pin.TruncateMiddle)}, "* /var/l…ata.db"},
+		{"none", "12", "Downloading packages", []pin.Option{pin.WithTruncation(pin.TruncateNone)}, "* Downloading packages"},
+		{"prefix", "16", "Downloading packages", []pin.Option{pin.WithPrefix("npm")}, "npm › * Downlo…"},
+		{"right", "12", "Downloading packages", []pin.Option{pin.WithPosition(pin.PositionRight)}, "Downloa… * "},
+		{"wide", "12", "下载软件包和依赖", nil, "* 下载软件…"},
+		{"wide boundary", "11", "下载软件包和依赖", nil, "* 下载软…"},
+		{"combining", "8", "Café au lait", nil, "* Café…"},
+		{"emoji", "10", "🚀🚀🚀🚀🚀", nil, "* 🚀🚀🚀…"},
+		{"unknown width", "", "Downloading packages", nil, "* Downloading packages"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderFrame(t, tt.columns, tt.message, tt.opts...); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTruncationKeepsFinalMessage(t *testing.T) {
+	pin.ForceInteractive = true
+	defer func() { pin.ForceInteractive = false }()
+	_ = os.Setenv("COLUMNS", "10")
+	defer os.Unsetenv("COLUMNS")
+
+	var buf syncBuffer
+	p := pin.New("Working", pin.WithWriter(&buf))
+	cancel := p.Start(context.Background())
+	defer cancel()
+	p.Stop("Downloaded all packages")
+
+	if !strings.Contains(buf.String(), "Downloaded all packages") {
+		t.Errorf("Expected the full final message, got %q", buf.String())
+	}
+}